@@ -48,7 +48,7 @@ type Preferences struct {
 }
 
 func (s *AccountService) GetMyProfile(ctx context.Context) (*User, *Response, error) {
-	u := fmt.Sprint("/api/account")
+	u := fmt.Sprint("api/account")
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
@@ -66,7 +66,7 @@ func (s *AccountService) GetMyProfile(ctx context.Context) (*User, *Response, er
 }
 
 func (s *AccountService) GetMyEmail(ctx context.Context) (string, *Response, error) {
-	u := fmt.Sprint("/api/account/email")
+	u := fmt.Sprint("api/account/email")
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
@@ -91,7 +91,7 @@ func (s *AccountService) GetMyPreferences(ctx context.Context) (*Preferences, *R
 		Pref *Preferences `json:"prefs"`
 	}
 
-	u := fmt.Sprint("/api/account/preferences")
+	u := fmt.Sprint("api/account/preferences")
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
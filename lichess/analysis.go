@@ -0,0 +1,65 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type AnalysisService service
+
+type CloudEvalOptions struct {
+	// MultiPv is the number of variations, 1 when unset.
+	MultiPv int     `url:"multiPv,omitempty"`
+	Variant Variant `url:"variant,omitempty"`
+}
+
+type CloudEval struct {
+	Fen    string `json:"fen"`
+	Knodes int    `json:"knodes"`
+	Depth  int    `json:"depth"`
+	Pvs    []*Pv  `json:"pvs"`
+}
+
+// Pv is a principal variation, scored either in centipawns or as a mate in
+// the given number of moves, from the point of view of white.
+type Pv struct {
+	// Moves are space separated UCI moves.
+	Moves string `json:"moves"`
+	Cp    *int   `json:"cp,omitempty"`
+	Mate  *int   `json:"mate,omitempty"`
+}
+
+// CloudEval returns the cached evaluation of a position. A *NoCloudEvalError
+// is returned when the position was never evaluated.
+func (s *AnalysisService) CloudEval(
+	ctx context.Context, fen string, opts CloudEvalOptions,
+) (*CloudEval, *Response, error) {
+	u, err := addOptions("api/cloud-eval", struct {
+		Fen string `url:"fen"`
+		CloudEvalOptions
+	}{fen, opts})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	eval := new(CloudEval)
+	resp, err := s.client.Do(ctx, req, eval)
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, resp, &NoCloudEvalError{Fen: fen}
+	}
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return eval, resp, nil
+}
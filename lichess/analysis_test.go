@@ -0,0 +1,64 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnalysisService_CloudEval(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	fen := "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3"
+
+	mux.HandleFunc("/api/cloud-eval", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.Query().Get("multiPv"), "2"; got != want {
+			t.Errorf("multiPv is %q, want %q", got, want)
+		}
+
+		if r.URL.Query().Get("fen") != fen {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"No cloud evaluation available for that position"}`)
+
+			return
+		}
+
+		fmt.Fprintf(w, `
+		{
+		  "fen": %q,
+		  "knodes": 13683,
+		  "depth": 22,
+		  "pvs": [{"moves": "f1b5 a7a6 b5a4", "cp": 34}, {"moves": "d2d4 e5d4", "mate": -7}]
+		}
+		`, fen)
+	})
+
+	eval, _, err := client.Analysis.CloudEval(context.Background(), fen, CloudEvalOptions{MultiPv: 2})
+
+	if err != nil {
+		t.Fatalf("Analysis.CloudEval returned error: %v", err)
+	}
+
+	want := &CloudEval{
+		Fen:    fen,
+		Knodes: 13683,
+		Depth:  22,
+		Pvs:    []*Pv{{Moves: "f1b5 a7a6 b5a4", Cp: Int(34)}, {Moves: "d2d4 e5d4", Mate: Int(-7)}},
+	}
+
+	if diff := cmp.Diff(eval, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+
+	_, _, err = client.Analysis.CloudEval(context.Background(), "8/8/8/8/8/8/8/8 w - - 0 1", CloudEvalOptions{MultiPv: 2})
+
+	if _, ok := err.(*NoCloudEvalError); !ok {
+		t.Errorf("Analysis.CloudEval returned error %v, want *NoCloudEvalError", err)
+	}
+}
@@ -0,0 +1,308 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type BroadcastsService service
+
+type BroadcastTour struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	Markup      string    `json:"markup,omitempty"`
+	URL         string    `json:"url"`
+	Tier        int       `json:"tier,omitempty"`
+	Image       string    `json:"image,omitempty"`
+	CreatedAt   Timestamp `json:"createdAt"`
+}
+
+type BroadcastRound struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	URL      string    `json:"url"`
+	Ongoing  bool      `json:"ongoing,omitempty"`
+	Finished bool      `json:"finished,omitempty"`
+	Delay    int       `json:"delay,omitempty"`
+	StartsAt Timestamp `json:"startsAt"`
+	// CreatedAt is only sent for the rounds of the current user.
+	CreatedAt Timestamp `json:"createdAt"`
+}
+
+// Broadcast is a broadcast tournament with its rounds.
+type Broadcast struct {
+	Tour   *BroadcastTour    `json:"tour"`
+	Rounds []*BroadcastRound `json:"rounds"`
+}
+
+type BroadcastStudy struct {
+	Writeable bool `json:"writeable"`
+}
+
+type BroadcastGame struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Fen      string                 `json:"fen"`
+	LastMove string                 `json:"lastMove,omitempty"`
+	Status   string                 `json:"status,omitempty"`
+	Players  []*BroadcastGamePlayer `json:"players"`
+}
+
+type BroadcastGamePlayer struct {
+	Name   string `json:"name"`
+	Title  string `json:"title,omitempty"`
+	Rating int    `json:"rating,omitempty"`
+	Clock  int    `json:"clock,omitempty"`
+	Fed    string `json:"fed,omitempty"`
+}
+
+// BroadcastRoundInfo is a round with its tournament. Games are only sent by
+// RoundInfo.
+type BroadcastRoundInfo struct {
+	Round *BroadcastRound  `json:"round"`
+	Tour  *BroadcastTour   `json:"tour"`
+	Study *BroadcastStudy  `json:"study,omitempty"`
+	Games []*BroadcastGame `json:"games,omitempty"`
+}
+
+type BroadcastTourOptions struct {
+	Name        string `url:"name"`
+	Description string `url:"description"`
+	// Markdown is the long description of the tournament.
+	Markdown        string `url:"markdown,omitempty"`
+	Tier            int    `url:"tier,omitempty"`
+	AutoLeaderboard *bool  `url:"autoLeaderboard,omitempty"`
+}
+
+// BroadcastRoundOptions configures a round. Games are either synced by
+// lichess from SyncURL, polled every Period seconds, or pushed with PushPGN.
+type BroadcastRoundOptions struct {
+	Name     string    `url:"name"`
+	SyncURL  string    `url:"syncUrl,omitempty"`
+	Period   int       `url:"period,omitempty"`
+	StartsAt time.Time `url:"startsAt,omitempty"`
+	// Delay holds back the moves for the given number of seconds.
+	Delay int `url:"delay,omitempty"`
+}
+
+// List streams the official broadcasts, ongoing ones first. At most nb are
+// returned, 20 when nb is 0.
+func (s *BroadcastsService) List(ctx context.Context, nb int) (<-chan *Broadcast, <-chan error) {
+	bch := make(chan *Broadcast)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(bch)
+			close(errCh)
+		}()
+
+		err := s.stream(ctx, "api/broadcast", nb, func(line json.RawMessage) error {
+			b := new(Broadcast)
+			if err := json.Unmarshal(line, b); err != nil {
+				return err
+			}
+
+			select {
+			case bch <- b:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return bch, errCh
+}
+
+// MyRounds streams the rounds of the broadcasts the current user can write to.
+func (s *BroadcastsService) MyRounds(ctx context.Context, nb int) (<-chan *BroadcastRoundInfo, <-chan error) {
+	rch := make(chan *BroadcastRoundInfo)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(rch)
+			close(errCh)
+		}()
+
+		err := s.stream(ctx, "api/broadcast/my-rounds", nb, func(line json.RawMessage) error {
+			r := new(BroadcastRoundInfo)
+			if err := json.Unmarshal(line, r); err != nil {
+				return err
+			}
+
+			select {
+			case rch <- r:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return rch, errCh
+}
+
+func (s *BroadcastsService) stream(ctx context.Context, path string, nb int, fn func(json.RawMessage) error) error {
+	u, err := addOptions(path, struct {
+		Nb int `url:"nb,omitempty"`
+	}{nb})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+	return s.client.stream(ctx, req, fn)
+}
+
+func (s *BroadcastsService) Get(ctx context.Context, tourID string) (*Broadcast, *Response, error) {
+	b := new(Broadcast)
+	resp, err := s.do(ctx, "GET", fmt.Sprintf("api/broadcast/%v", tourID), nil, b)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return b, resp, nil
+}
+
+func (s *BroadcastsService) Create(ctx context.Context, opts BroadcastTourOptions) (*Broadcast, *Response, error) {
+	b := new(Broadcast)
+	resp, err := s.do(ctx, "POST", "broadcast/new", Form{V: opts}, b)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return b, resp, nil
+}
+
+// Update replaces the settings of a broadcast tournament.
+func (s *BroadcastsService) Update(ctx context.Context, tourID string, opts BroadcastTourOptions) (*Response, error) {
+	return s.do(ctx, "POST", fmt.Sprintf("broadcast/%v/edit", tourID), Form{V: opts}, nil)
+}
+
+func (s *BroadcastsService) CreateRound(
+	ctx context.Context, tourID string, opts BroadcastRoundOptions,
+) (*BroadcastRoundInfo, *Response, error) {
+	r := new(BroadcastRoundInfo)
+	resp, err := s.do(ctx, "POST", fmt.Sprintf("broadcast/%v/new", tourID), Form{V: opts}, r)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// UpdateRound replaces the settings of a round, e.g. to change its source or
+// start time.
+func (s *BroadcastsService) UpdateRound(
+	ctx context.Context, roundID string, opts BroadcastRoundOptions,
+) (*BroadcastRoundInfo, *Response, error) {
+	r := new(BroadcastRoundInfo)
+	resp, err := s.do(ctx, "POST", fmt.Sprintf("broadcast/round/%v/edit", roundID), Form{V: opts}, r)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RoundInfo returns a round with its games. The slugs only make the URL
+// readable and may be any non-empty string.
+func (s *BroadcastsService) RoundInfo(
+	ctx context.Context, tourSlug, roundSlug, roundID string,
+) (*BroadcastRoundInfo, *Response, error) {
+	r := new(BroadcastRoundInfo)
+	resp, err := s.do(ctx, "GET", fmt.Sprintf("api/broadcast/%v/%v/%v", tourSlug, roundSlug, roundID), nil, r)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+func (s *BroadcastsService) do(ctx context.Context, method, u string, body, v interface{}) (*Response, error) {
+	req, err := s.client.NewRequest(method, u, body)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.client.Do(ctx, req, v)
+}
+
+// BroadcastPushResult reports how lichess read one of the pushed games.
+type BroadcastPushResult struct {
+	Tags  map[string]string `json:"tags"`
+	Moves int               `json:"moves"`
+	Error string            `json:"error,omitempty"`
+}
+
+// PushPGN updates the games of a round from PGN, for rounds without a
+// SyncURL.
+func (s *BroadcastsService) PushPGN(
+	ctx context.Context, roundID, pgn string,
+) ([]*BroadcastPushResult, *Response, error) {
+	var pushed struct {
+		Games []*BroadcastPushResult `json:"games"`
+	}
+
+	resp, err := s.do(ctx, "POST", fmt.Sprintf("api/broadcast/round/%v/push", roundID), pgn, &pushed)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return pushed.Games, resp, nil
+}
+
+// ExportRoundPGN writes the current PGN of all the games of a round into w.
+func (s *BroadcastsService) ExportRoundPGN(ctx context.Context, roundID string, w io.Writer) (*Response, error) {
+	return s.pgn(ctx, fmt.Sprintf("api/broadcast/round/%v.pgn", roundID), w)
+}
+
+// StreamRoundPGN writes the PGN of the games of a round into w as they are
+// updated, until the round ends or ctx is cancelled.
+func (s *BroadcastsService) StreamRoundPGN(ctx context.Context, roundID string, w io.Writer) (*Response, error) {
+	return s.pgn(ctx, fmt.Sprintf("api/stream/broadcast/round/%v.pgn", roundID), w)
+}
+
+func (s *BroadcastsService) pgn(ctx context.Context, u string, w io.Writer) (*Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypePGN)
+
+	return s.client.streamTo(ctx, req, w)
+}
@@ -0,0 +1,274 @@
+package lichess
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBroadcastsService_List(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/broadcast", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RawQuery, "nb=2"; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `
+		{
+		  "tour": {
+		    "id": "QMNmKbSY",
+		    "name": "Club League",
+		    "slug": "club-league",
+		    "description": "Season 3",
+		    "createdAt": 1600000000000,
+		    "url": "https://lichess.org/broadcast/club-league/QMNmKbSY"
+		  },
+		  "rounds": [
+		    {
+		      "id": "abcdef12",
+		      "name": "Round 1",
+		      "slug": "round-1",
+		      "url": "https://lichess.org/broadcast/club-league/round-1/abcdef12",
+		      "ongoing": true,
+		      "startsAt": 1610000000000
+		    }
+		  ]
+		}
+		{
+		  "tour": {
+		    "id": "xyzxyzxy",
+		    "name": "Open",
+		    "slug": "open",
+		    "description": "",
+		    "createdAt": 1500000000000,
+		    "url": "https://lichess.org/broadcast/open/xyzxyzxy"
+		  },
+		  "rounds": []
+		}
+`)
+	})
+
+	bch, errCh := client.Broadcasts.List(context.Background(), 2)
+
+	var broadcasts []*Broadcast
+	for b := range bch {
+		broadcasts = append(broadcasts, b)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Broadcasts.List returned error: %v", err)
+	}
+
+	if len(broadcasts) != 2 {
+		t.Fatalf("Broadcasts.List returned %d broadcasts, want 2", len(broadcasts))
+	}
+
+	want := &Broadcast{
+		Tour: &BroadcastTour{
+			ID:          "QMNmKbSY",
+			Name:        "Club League",
+			Slug:        "club-league",
+			Description: "Season 3",
+			URL:         "https://lichess.org/broadcast/club-league/QMNmKbSY",
+			CreatedAt:   fromMillis(1600000000000),
+		},
+		Rounds: []*BroadcastRound{{
+			ID:       "abcdef12",
+			Name:     "Round 1",
+			Slug:     "round-1",
+			URL:      "https://lichess.org/broadcast/club-league/round-1/abcdef12",
+			Ongoing:  true,
+			StartsAt: fromMillis(1610000000000),
+		}},
+	}
+
+	if diff := cmp.Diff(broadcasts[0], want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestBroadcastsService_CreateRound(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	startsAt := time.Date(2021, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/broadcast/QMNmKbSY/new", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		want := map[string]string{
+			"name":     "Round 2",
+			"syncUrl":  "https://example.com/round2.pgn",
+			"startsAt": "1619892000000",
+			"delay":    "",
+		}
+
+		for field, value := range want {
+			if got := r.FormValue(field); got != value {
+				t.Errorf("%v is %q, want %q", field, got, value)
+			}
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "round": {
+		    "id": "ghijkl34",
+		    "name": "Round 2",
+		    "slug": "round-2",
+		    "url": "https://lichess.org/broadcast/club-league/round-2/ghijkl34",
+		    "startsAt": 1619892000000
+		  },
+		  "tour": {
+		    "id": "QMNmKbSY",
+		    "name": "Club League",
+		    "slug": "club-league",
+		    "description": "Season 3",
+		    "createdAt": 1600000000000,
+		    "url": "https://lichess.org/broadcast/club-league/QMNmKbSY"
+		  },
+		  "study": {"writeable": true}
+		}
+		`)
+	})
+
+	opts := BroadcastRoundOptions{Name: "Round 2", SyncURL: "https://example.com/round2.pgn", StartsAt: startsAt}
+	round, _, err := client.Broadcasts.CreateRound(context.Background(), "QMNmKbSY", opts)
+
+	if err != nil {
+		t.Fatalf("Broadcasts.CreateRound returned error: %v", err)
+	}
+
+	if round.Round.ID != "ghijkl34" || !round.Round.StartsAt.Equal(startsAt) ||
+		!round.Study.Writeable || round.Tour.ID != "QMNmKbSY" {
+		t.Errorf("Broadcasts.CreateRound returned %+v", round)
+	}
+}
+
+func TestBroadcastsService_PushPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	pgn := "[White \"A\"]\n[Black \"B\"]\n\n1. e4 *"
+
+	mux.HandleFunc("/api/broadcast/round/ghijkl34/push", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if got := string(body); got != pgn {
+			t.Errorf("body is %q, want %q", got, pgn)
+		}
+
+		fmt.Fprint(w, `{"games":[{"tags":{"White":"A","Black":"B"},"moves":1},{"tags":{},"moves":0,"error":"Invalid PGN"}]}`)
+	})
+
+	results, _, err := client.Broadcasts.PushPGN(context.Background(), "ghijkl34", pgn)
+
+	if err != nil {
+		t.Fatalf("Broadcasts.PushPGN returned error: %v", err)
+	}
+
+	want := []*BroadcastPushResult{
+		{Tags: map[string]string{"White": "A", "Black": "B"}, Moves: 1},
+		{Tags: map[string]string{}, Error: "Invalid PGN"},
+	}
+
+	if diff := cmp.Diff(results, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestBroadcastsService_RoundInfo(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/broadcast/club-league/round-1/abcdef12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		{
+		  "round": {
+		    "id": "abcdef12",
+		    "name": "Round 1",
+		    "slug": "round-1",
+		    "url": "",
+		    "finished": true
+		  },
+		  "tour": {
+		    "id": "QMNmKbSY",
+		    "name": "Club League",
+		    "slug": "club-league",
+		    "description": "",
+		    "url": ""
+		  },
+		  "study": {"writeable": false},
+		  "games": [
+		    {
+		      "id": "g1",
+		      "name": "A - B",
+		      "fen": "8/8/8/8/8/8/8/8 w - - 0 1",
+		      "players": [
+		        {"name": "A", "rating": 2100, "clock": 5000},
+		        {"name": "B", "title": "FM"}
+		      ],
+		      "lastMove": "e2e4",
+		      "status": "1-0"
+		    }
+		  ]
+		}
+		`)
+	})
+
+	round, _, err := client.Broadcasts.RoundInfo(context.Background(), "club-league", "round-1", "abcdef12")
+
+	if err != nil {
+		t.Fatalf("Broadcasts.RoundInfo returned error: %v", err)
+	}
+
+	want := []*BroadcastGame{{
+		ID:       "g1",
+		Name:     "A - B",
+		Fen:      "8/8/8/8/8/8/8/8 w - - 0 1",
+		LastMove: "e2e4",
+		Status:   "1-0",
+		Players:  []*BroadcastGamePlayer{{Name: "A", Rating: 2100, Clock: 5000}, {Name: "B", Title: "FM"}},
+	}}
+
+	if diff := cmp.Diff(round.Games, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+
+	if !round.Round.Finished {
+		t.Errorf("Broadcasts.RoundInfo returned unfinished round %+v", round.Round)
+	}
+}
+
+func TestBroadcastsService_StreamRoundPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/stream/broadcast/round/abcdef12.pgn", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept header is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, "1. e4 *\n\n\n1. e4 e5 *\n\n\n")
+	})
+
+	var buf bytes.Buffer
+
+	if _, err := client.Broadcasts.StreamRoundPGN(context.Background(), "abcdef12", &buf); err != nil {
+		t.Fatalf("Broadcasts.StreamRoundPGN returned error: %v", err)
+	}
+
+	if got, want := buf.String(), "1. e4 *\n\n\n1. e4 e5 *\n\n\n"; got != want {
+		t.Errorf("Broadcasts.StreamRoundPGN wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,234 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Color int8
+
+const (
+	White Color = iota
+	Black
+)
+
+func (c Color) Other() Color {
+	return c ^ 1
+}
+
+func (c Color) String() string {
+	if c == White {
+		return "white"
+	}
+
+	return "black"
+}
+
+type PieceType int8
+
+const (
+	NoPieceType PieceType = iota
+	Pawn
+	Knight
+	Bishop
+	Rook
+	Queen
+	King
+)
+
+const pieceLetters = " pnbrqk"
+
+// String returns the lower case FEN letter of the piece type.
+func (t PieceType) String() string {
+	return string(pieceLetters[t])
+}
+
+func parsePieceType(r rune) (PieceType, bool) {
+	i := strings.IndexRune(pieceLetters, r)
+	if i < 1 {
+		return NoPieceType, false
+	}
+
+	return PieceType(i), true
+}
+
+type Piece struct {
+	Type  PieceType
+	Color Color
+}
+
+var NoPiece = Piece{}
+
+// String returns the FEN letter of the piece, upper case for white.
+func (p Piece) String() string {
+	if p.Type == NoPieceType {
+		return ""
+	}
+
+	if p.Color == White {
+		return strings.ToUpper(p.Type.String())
+	}
+
+	return p.Type.String()
+}
+
+type Square int8
+
+const NoSquare Square = -1
+
+const (
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	F1
+	G1
+	H1
+	A2
+	B2
+	C2
+	D2
+	E2
+	F2
+	G2
+	H2
+	A3
+	B3
+	C3
+	D3
+	E3
+	F3
+	G3
+	H3
+	A4
+	B4
+	C4
+	D4
+	E4
+	F4
+	G4
+	H4
+	A5
+	B5
+	C5
+	D5
+	E5
+	F5
+	G5
+	H5
+	A6
+	B6
+	C6
+	D6
+	E6
+	F6
+	G6
+	H6
+	A7
+	B7
+	C7
+	D7
+	E7
+	F7
+	G7
+	H7
+	A8
+	B8
+	C8
+	D8
+	E8
+	F8
+	G8
+	H8
+)
+
+func NewSquare(file, rank int) Square {
+	return Square(rank*8 + file)
+}
+
+// File returns the file of the square, 0 for the a-file.
+func (s Square) File() int {
+	return int(s) % 8
+}
+
+// Rank returns the rank of the square, 0 for the first rank.
+func (s Square) Rank() int {
+	return int(s) / 8
+}
+
+func (s Square) String() string {
+	if s < A1 || s > H8 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%c%c", 'a'+s.File(), '1'+s.Rank())
+}
+
+func ParseSquare(s string) (Square, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return NoSquare, fmt.Errorf("chess: invalid square %q", s)
+	}
+
+	return NewSquare(int(s[0]-'a'), int(s[1]-'1')), nil
+}
+
+// offset returns the square df files and dr ranks away from s, if it is on
+// the board.
+func offset(s Square, df, dr int) (Square, bool) {
+	f, r := s.File()+df, s.Rank()+dr
+	if f < 0 || f > 7 || r < 0 || r > 7 {
+		return NoSquare, false
+	}
+
+	return NewSquare(f, r), true
+}
+
+var (
+	knightDeltas = [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	kingDeltas   = [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	rookDirs     = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	bishopDirs   = [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+	knightTargets [64][]Square
+	kingTargets   [64][]Square
+	rookRays      [64][][]Square
+	bishopRays    [64][][]Square
+)
+
+func init() {
+	for s := A1; s <= H8; s++ {
+		knightTargets[s] = targets(s, knightDeltas)
+		kingTargets[s] = targets(s, kingDeltas)
+		rookRays[s] = rays(s, rookDirs)
+		bishopRays[s] = rays(s, bishopDirs)
+	}
+}
+
+func targets(s Square, deltas [][2]int) []Square {
+	var out []Square
+
+	for _, d := range deltas {
+		if t, ok := offset(s, d[0], d[1]); ok {
+			out = append(out, t)
+		}
+	}
+
+	return out
+}
+
+func rays(s Square, dirs [][2]int) [][]Square {
+	out := make([][]Square, 0, len(dirs))
+
+	for _, d := range dirs {
+		var ray []Square
+
+		for t, ok := offset(s, d[0], d[1]); ok; t, ok = offset(t, d[0], d[1]) {
+			ray = append(ray, t)
+		}
+
+		out = append(out, ray)
+	}
+
+	return out
+}
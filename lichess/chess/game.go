@@ -0,0 +1,180 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Termination int
+
+const (
+	NotTerminated Termination = iota
+	Checkmate
+	Stalemate
+	InsufficientMaterial
+	ThreefoldRepetition
+	FiftyMoveRule
+	// VariantEnd is a win or draw by a rule of the variant, see
+	// Position.VariantOutcome.
+	VariantEnd
+)
+
+// Game is a sequence of moves from a starting position, kept to detect
+// repetitions.
+type Game struct {
+	positions []*Position
+	moves     []Move
+}
+
+func NewGame(start *Position) *Game {
+	if start == nil {
+		start = StartingPosition()
+	}
+
+	return &Game{positions: []*Position{start}}
+}
+
+// Replay plays a space separated list of SAN moves, the format of
+// lichess.Game.Moves, from start.
+func Replay(start *Position, moves string) (*Game, error) {
+	g := NewGame(start)
+
+	for _, san := range strings.Fields(moves) {
+		if err := g.PlaySAN(san); err != nil {
+			return g, fmt.Errorf("ply %d: %v", len(g.moves)+1, err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Game) Position() *Position {
+	return g.positions[len(g.positions)-1]
+}
+
+// Positions returns the starting position followed by the position after
+// every move.
+func (g *Game) Positions() []*Position {
+	return g.positions
+}
+
+func (g *Game) Moves() []Move {
+	return g.moves
+}
+
+func (g *Game) Play(m Move) error {
+	pos := g.Position()
+
+	if !pos.IsLegal(m) {
+		return fmt.Errorf("chess: illegal move %v in %v", pos.UCI(m), pos.FEN())
+	}
+
+	g.positions = append(g.positions, pos.Play(m))
+	g.moves = append(g.moves, m)
+
+	return nil
+}
+
+func (g *Game) PlaySAN(san string) error {
+	m, err := g.Position().ParseSAN(san)
+	if err != nil {
+		return err
+	}
+
+	return g.Play(m)
+}
+
+func (g *Game) PlayUCI(uci string) error {
+	m, err := g.Position().ParseUCI(uci)
+	if err != nil {
+		return err
+	}
+
+	return g.Play(m)
+}
+
+// SAN returns the moves of the game in standard algebraic notation.
+func (g *Game) SAN() []string {
+	out := make([]string, len(g.moves))
+
+	for i, m := range g.moves {
+		out[i] = g.positions[i].SAN(m)
+	}
+
+	return out
+}
+
+// Repetitions returns how many times the current position has occurred,
+// counting positions as equal when the same moves are available.
+func (g *Game) Repetitions() int {
+	key := g.Position().repetitionKey()
+	n := 0
+
+	for _, p := range g.positions {
+		if p.repetitionKey() == key {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Termination returns why the game ended on the board, if it did. Draws by
+// repetition and the fifty-move rule are reported as soon as they can be
+// claimed.
+func (g *Game) Termination() Termination {
+	pos := g.Position()
+
+	if ended, _, _ := pos.VariantOutcome(); ended {
+		return VariantEnd
+	}
+
+	switch {
+	case pos.IsCheckmate():
+		return Checkmate
+	case pos.IsStalemate():
+		return Stalemate
+	case pos.IsInsufficientMaterial():
+		return InsufficientMaterial
+	case g.Repetitions() >= 3:
+		return ThreefoldRepetition
+	case pos.HalfmoveClock() >= 100:
+		return FiftyMoveRule
+	default:
+		return NotTerminated
+	}
+}
+
+// Result returns the result in PGN notation: 1-0, 0-1, 1/2-1/2 or * while the
+// game is not over.
+func (g *Game) Result() string {
+	switch g.Termination() {
+	case NotTerminated:
+		return "*"
+	case VariantEnd:
+		_, winner, draw := g.Position().VariantOutcome()
+
+		switch {
+		case draw:
+			return "1/2-1/2"
+		case winner == White:
+			return "1-0"
+		default:
+			return "0-1"
+		}
+	case Checkmate:
+		if g.Position().Turn() == White {
+			return "0-1"
+		}
+
+		return "1-0"
+	default:
+		return "1/2-1/2"
+	}
+}
+
+func (p *Position) repetitionKey() string {
+	fields := strings.Fields(p.FEN())
+
+	return strings.Join(fields[:len(fields)-2], " ")
+}
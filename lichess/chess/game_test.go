@@ -0,0 +1,91 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReplay(t *testing.T) {
+	g, err := Replay(nil, "e4 e5 Qh5 Nc6 Bc4 Nf6 Qxf7#")
+
+	if err != nil {
+		t.Fatalf("Replay returned an error: %v", err)
+	}
+
+	if got, want := g.Termination(), Checkmate; got != want {
+		t.Errorf("Termination() = %v, want %v", got, want)
+	}
+
+	if got, want := g.Result(), "1-0"; got != want {
+		t.Errorf("Result() = %v, want %v", got, want)
+	}
+
+	want := []string{"e4", "e5", "Qh5", "Nc6", "Bc4", "Nf6", "Qxf7#"}
+	if diff := cmp.Diff(g.SAN(), want); diff != "" {
+		t.Errorf("Moves do not match. Diff: %+v", diff)
+	}
+
+	if got, want := len(g.Positions()), 8; got != want {
+		t.Errorf("Replay kept %d positions, want %d", got, want)
+	}
+}
+
+func TestGame_Repetition(t *testing.T) {
+	g, err := Replay(nil, "Nf3 Nf6 Ng1 Ng8 Nf3 Nf6 Ng1")
+
+	if err != nil {
+		t.Fatalf("Replay returned an error: %v", err)
+	}
+
+	if got := g.Termination(); got != NotTerminated {
+		t.Errorf("Termination() = %v, want %v", got, NotTerminated)
+	}
+
+	if err := g.PlaySAN("Ng8"); err != nil {
+		t.Fatalf("PlaySAN returned an error: %v", err)
+	}
+
+	if got, want := g.Repetitions(), 3; got != want {
+		t.Errorf("Repetitions() = %v, want %v", got, want)
+	}
+
+	if got := g.Result(); got != "1/2-1/2" {
+		t.Errorf("Result() = %v, want 1/2-1/2", got)
+	}
+}
+
+func TestGame_Terminations(t *testing.T) {
+	tests := []struct {
+		fen  string
+		want Termination
+	}{
+		{"7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", Stalemate},
+		{"7k/8/6K1/8/8/8/8/2B5 b - - 0 1", InsufficientMaterial},
+		{"7k/8/6K1/8/8/8/8/2R5 b - - 100 80", FiftyMoveRule},
+		{"7k/8/6K1/8/8/8/8/2R5 b - - 99 80", NotTerminated},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("ParseFEN returned an error: %v", err)
+		}
+
+		if got := NewGame(p).Termination(); got != tt.want {
+			t.Errorf("Termination(%q) = %v, want %v", tt.fen, got, tt.want)
+		}
+	}
+}
+
+func TestGame_PlayIllegal(t *testing.T) {
+	g := NewGame(nil)
+
+	if err := g.Play(Move{From: E2, To: E5}); err == nil {
+		t.Error("Play should reject an illegal move")
+	}
+
+	if err := g.PlayUCI("e7e5"); err == nil {
+		t.Error("PlayUCI should reject a move of the wrong side")
+	}
+}
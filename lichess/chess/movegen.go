@@ -0,0 +1,594 @@
+package chess
+
+// Move is a move from one square to another. Castling is encoded as the king
+// capturing its own rook, which works the same way in Chess960.
+type Move struct {
+	From      Square
+	To        Square
+	Promotion PieceType
+	// Drop is the piece put on To in crazyhouse; From is then unused.
+	Drop PieceType
+}
+
+func (m Move) IsDrop() bool {
+	return m.Drop != NoPieceType
+}
+
+var promotionTypes = []PieceType{Queen, Rook, Bishop, Knight}
+
+// IsCastling reports whether m is a castling move in p.
+func (p *Position) IsCastling(m Move) bool {
+	if m.IsDrop() {
+		return false
+	}
+
+	from, to := p.board[m.From], p.board[m.To]
+
+	return from.Type == King && to.Type == Rook && from.Color == to.Color
+}
+
+// IsCapture reports whether m captures a piece in p, en passant included.
+func (p *Position) IsCapture(m Move) bool {
+	if m.IsDrop() || p.IsCastling(m) {
+		return false
+	}
+
+	return p.board[m.To].Type != NoPieceType || p.isEnPassant(m)
+}
+
+func (p *Position) isEnPassant(m Move) bool {
+	return !m.IsDrop() && m.To == p.epSquare && p.board[m.From].Type == Pawn && m.From.File() != m.To.File() &&
+		p.board[m.To].Type == NoPieceType
+}
+
+// InCheck reports whether the side to move is in check. There is no check in
+// antichess, nor in atomic while the kings touch.
+func (p *Position) InCheck() bool {
+	return p.variant != Antichess && p.kingAttacked(p.turn)
+}
+
+func (p *Position) kingAttacked(c Color) bool {
+	king := p.kingSquare(c)
+	if king == NoSquare {
+		return false
+	}
+
+	if p.variant == Atomic {
+		for _, s := range kingTargets[king] {
+			if p.board[s] == (Piece{Type: King, Color: c.Other()}) {
+				return false
+			}
+		}
+	}
+
+	return p.attacked(king, c.Other())
+}
+
+func (p *Position) IsCheckmate() bool {
+	return p.InCheck() && len(p.LegalMoves()) == 0
+}
+
+func (p *Position) IsStalemate() bool {
+	return !p.InCheck() && len(p.LegalMoves()) == 0
+}
+
+// IsInsufficientMaterial reports whether neither side can possibly win: bare
+// kings, a single minor piece, or bishops all on the same color. Only bare
+// kings count in three-check and atomic, and the other variants are never
+// drawn this way.
+func (p *Position) IsInsufficientMaterial() bool {
+	var knights, bishops [2]int
+
+	lightBishops, darkBishops := 0, 0
+
+	for s, pc := range p.board {
+		switch pc.Type {
+		case Pawn, Rook, Queen:
+			return false
+		case Knight:
+			knights[pc.Color]++
+		case Bishop:
+			bishops[pc.Color]++
+
+			if (Square(s).File()+Square(s).Rank())%2 == 0 {
+				darkBishops++
+			} else {
+				lightBishops++
+			}
+		}
+	}
+
+	minors := knights[White] + knights[Black] + bishops[White] + bishops[Black]
+
+	switch p.variant {
+	case Standard, Chess960:
+	case ThreeCheck, Atomic:
+		return minors == 0
+	default:
+		return false
+	}
+
+	switch {
+	case minors <= 1:
+		return true
+	case knights[White]+knights[Black] == 0:
+		return lightBishops == 0 || darkBishops == 0
+	default:
+		return false
+	}
+}
+
+// attacked reports whether a piece of color by attacks s. Kings never attack
+// in atomic, where they cannot capture.
+func (p *Position) attacked(s Square, by Color) bool {
+	dir := -1
+	if by == Black {
+		dir = 1
+	}
+
+	for _, df := range []int{-1, 1} {
+		if t, ok := offset(s, df, dir); ok && p.board[t] == (Piece{Type: Pawn, Color: by}) {
+			return true
+		}
+	}
+
+	for _, t := range knightTargets[s] {
+		if p.board[t] == (Piece{Type: Knight, Color: by}) {
+			return true
+		}
+	}
+
+	if p.variant != Atomic {
+		for _, t := range kingTargets[s] {
+			if p.board[t] == (Piece{Type: King, Color: by}) {
+				return true
+			}
+		}
+	}
+
+	return p.attackedOnRays(s, by, rookRays[s], Rook) || p.attackedOnRays(s, by, bishopRays[s], Bishop)
+}
+
+func (p *Position) attackedOnRays(s Square, by Color, rays [][]Square, slider PieceType) bool {
+	for _, ray := range rays {
+		for _, t := range ray {
+			pc := p.board[t]
+			if pc.Type == NoPieceType {
+				continue
+			}
+
+			if pc.Color == by && (pc.Type == slider || pc.Type == Queen) {
+				return true
+			}
+
+			break
+		}
+	}
+
+	return false
+}
+
+// LegalMoves returns every legal move of the side to move, or none once the
+// game ended by a variant rule.
+func (p *Position) LegalMoves() []Move {
+	if ended, _, _ := p.variantEnd(); ended {
+		return nil
+	}
+
+	return p.legalMoves()
+}
+
+func (p *Position) legalMoves() []Move {
+	pseudo := p.pseudoLegalMoves()
+	moves := pseudo[:0]
+	captures := false
+
+	for _, m := range pseudo {
+		if p.isLegal(m) {
+			moves = append(moves, m)
+			captures = captures || p.IsCapture(m)
+		}
+	}
+
+	// Captures are compulsory in antichess.
+	if p.variant == Antichess && captures {
+		forced := moves[:0]
+
+		for _, m := range moves {
+			if p.IsCapture(m) {
+				forced = append(forced, m)
+			}
+		}
+
+		moves = forced
+	}
+
+	return moves
+}
+
+// IsLegal reports whether m is one of the legal moves of p.
+func (p *Position) IsLegal(m Move) bool {
+	for _, legal := range p.LegalMoves() {
+		if legal == m {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Position) isLegal(m Move) bool {
+	next := p.play(m)
+
+	switch p.variant {
+	case Antichess:
+		return true
+	case Atomic:
+		if next.kingSquare(p.turn) == NoSquare {
+			return false
+		}
+
+		if next.kingSquare(p.turn.Other()) == NoSquare {
+			return true
+		}
+	case RacingKings:
+		if next.kingAttacked(next.turn) {
+			return false
+		}
+	}
+
+	return !next.kingAttacked(p.turn)
+}
+
+func (p *Position) pseudoLegalMoves() []Move {
+	moves := make([]Move, 0, 64)
+
+	for i, pc := range p.board {
+		if pc.Type == NoPieceType || pc.Color != p.turn {
+			continue
+		}
+
+		s := Square(i)
+
+		switch pc.Type {
+		case Pawn:
+			moves = p.pawnMoves(moves, s)
+		case Knight:
+			moves = p.stepMoves(moves, s, knightTargets[s])
+		case King:
+			moves = p.stepMoves(moves, s, kingTargets[s])
+			moves = p.castlingMoves(moves, s)
+		case Bishop:
+			moves = p.slideMoves(moves, s, bishopRays[s])
+		case Rook:
+			moves = p.slideMoves(moves, s, rookRays[s])
+		case Queen:
+			moves = p.slideMoves(moves, s, bishopRays[s])
+			moves = p.slideMoves(moves, s, rookRays[s])
+		}
+	}
+
+	if p.variant == Crazyhouse {
+		moves = p.dropMoves(moves)
+	}
+
+	return moves
+}
+
+func (p *Position) pawnMoves(moves []Move, s Square) []Move {
+	dir, startRank, lastRank := 1, 1, 7
+	if p.turn == Black {
+		dir, startRank, lastRank = -1, 6, 0
+	}
+
+	// The horde may also advance two squares from the first rank.
+	doubleStep := s.Rank() == startRank || p.variant == Horde && p.turn == White && s.Rank() == 0
+
+	promotions := promotionTypes
+	if p.variant == Antichess {
+		promotions = append([]PieceType{King}, promotionTypes...)
+	}
+
+	add := func(to Square) {
+		if to.Rank() != lastRank {
+			moves = append(moves, Move{From: s, To: to})
+			return
+		}
+
+		for _, t := range promotions {
+			moves = append(moves, Move{From: s, To: to, Promotion: t})
+		}
+	}
+
+	if to, ok := offset(s, 0, dir); ok && p.board[to].Type == NoPieceType {
+		add(to)
+
+		if to2, ok := offset(to, 0, dir); ok && doubleStep && p.board[to2].Type == NoPieceType {
+			add(to2)
+		}
+	}
+
+	for _, df := range []int{-1, 1} {
+		to, ok := offset(s, df, dir)
+		if !ok {
+			continue
+		}
+
+		if target := p.board[to]; target.Type != NoPieceType && target.Color != p.turn || to == p.epSquare {
+			add(to)
+		}
+	}
+
+	return moves
+}
+
+func (p *Position) stepMoves(moves []Move, s Square, targets []Square) []Move {
+	// Kings cannot capture in atomic.
+	kingInAtomic := p.variant == Atomic && p.board[s].Type == King
+
+	for _, to := range targets {
+		target := p.board[to]
+
+		if target.Type == NoPieceType || target.Color != p.turn && !kingInAtomic {
+			moves = append(moves, Move{From: s, To: to})
+		}
+	}
+
+	return moves
+}
+
+func (p *Position) slideMoves(moves []Move, s Square, rays [][]Square) []Move {
+	for _, ray := range rays {
+		for _, to := range ray {
+			target := p.board[to]
+
+			if target.Type == NoPieceType || target.Color != p.turn {
+				moves = append(moves, Move{From: s, To: to})
+			}
+
+			if target.Type != NoPieceType {
+				break
+			}
+		}
+	}
+
+	return moves
+}
+
+func (p *Position) dropMoves(moves []Move) []Move {
+	for t := Pawn; t < King; t++ {
+		if p.pockets[p.turn][t] == 0 {
+			continue
+		}
+
+		for i, pc := range p.board {
+			s := Square(i)
+
+			if pc.Type != NoPieceType || t == Pawn && (s.Rank() == 0 || s.Rank() == 7) {
+				continue
+			}
+
+			moves = append(moves, Move{To: s, Drop: t})
+		}
+	}
+
+	return moves
+}
+
+// castlingDestinations returns where the king and rook end up when castling
+// to the given side.
+func castlingDestinations(c Color, side int) (king, rook Square) {
+	rank := 0
+	if c == Black {
+		rank = 7
+	}
+
+	if side == kingSide {
+		return NewSquare(6, rank), NewSquare(5, rank)
+	}
+
+	return NewSquare(2, rank), NewSquare(3, rank)
+}
+
+func (p *Position) castlingMoves(moves []Move, king Square) []Move {
+	for side, rook := range p.castling[p.turn] {
+		if rook == NoSquare || p.board[rook] != (Piece{Type: Rook, Color: p.turn}) {
+			continue
+		}
+
+		kingTo, rookTo := castlingDestinations(p.turn, side)
+
+		if !p.castlingPathClear(king, rook, kingTo, rookTo) {
+			continue
+		}
+
+		// The king may not castle out of or through check.
+		safe := true
+
+		for _, s := range between(king, kingTo, true) {
+			if p.attacked(s, p.turn.Other()) && !p.atomicKingsTouch(s) {
+				safe = false
+				break
+			}
+		}
+
+		if safe {
+			moves = append(moves, Move{From: king, To: rook})
+		}
+	}
+
+	return moves
+}
+
+// atomicKingsTouch reports whether, in atomic, a king of the side to move on s
+// would touch the opponent king and so be safe from check.
+func (p *Position) atomicKingsTouch(s Square) bool {
+	if p.variant != Atomic {
+		return false
+	}
+
+	for _, t := range kingTargets[s] {
+		if p.board[t] == (Piece{Type: King, Color: p.turn.Other()}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// castlingPathClear checks that every square the king and rook cross or land
+// on is empty, apart from the king and rook themselves.
+func (p *Position) castlingPathClear(king, rook, kingTo, rookTo Square) bool {
+	for _, path := range [][]Square{between(king, kingTo, true), between(rook, rookTo, true)} {
+		for _, s := range path {
+			if s != king && s != rook && p.board[s].Type != NoPieceType {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// between returns the squares on the rank from a to b, b included and a
+// included only when withFrom is set.
+func between(a, b Square, withFrom bool) []Square {
+	var out []Square
+
+	if withFrom {
+		out = append(out, a)
+	}
+
+	step := Square(1)
+	if b < a {
+		step = -1
+	}
+
+	for s := a; s != b; {
+		s += step
+		out = append(out, s)
+	}
+
+	return out
+}
+
+// Play returns the position after m. The move is not validated; use IsLegal
+// or Game.Play for untrusted input.
+func (p *Position) Play(m Move) *Position {
+	next := p.play(m)
+
+	return &next
+}
+
+func (p *Position) play(m Move) Position {
+	next := *p
+	next.epSquare = NoSquare
+	next.halfmoves++
+
+	if p.turn == Black {
+		next.fullmoves++
+	}
+
+	next.turn = p.turn.Other()
+
+	switch {
+	case m.IsDrop():
+		next.board[m.To] = Piece{Type: m.Drop, Color: p.turn}
+		next.pockets[p.turn][m.Drop]--
+	case p.IsCastling(m):
+		p.playCastling(&next, m)
+	default:
+		p.playMove(&next, m)
+	}
+
+	if p.variant == ThreeCheck && next.kingAttacked(next.turn) {
+		next.checks[p.turn]++
+	}
+
+	return next
+}
+
+func (p *Position) playCastling(next *Position, m Move) {
+	pc := p.board[m.From]
+
+	side := queenSide
+	if m.To > m.From {
+		side = kingSide
+	}
+
+	kingTo, rookTo := castlingDestinations(pc.Color, side)
+	next.board[m.From], next.board[m.To] = NoPiece, NoPiece
+	next.board[kingTo], next.board[rookTo] = pc, Piece{Type: Rook, Color: pc.Color}
+	next.castling[pc.Color] = [2]Square{NoSquare, NoSquare}
+}
+
+func (p *Position) playMove(next *Position, m Move) {
+	pc := p.board[m.From]
+	captured := p.board[m.To]
+	capturedPromoted := p.promoted[m.To]
+
+	if captured.Type != NoPieceType || pc.Type == Pawn {
+		next.halfmoves = 0
+	}
+
+	if p.isEnPassant(m) {
+		behind := NewSquare(m.To.File(), m.From.Rank())
+		captured = next.board[behind]
+		next.board[behind] = NoPiece
+	}
+
+	startRank := 1
+	if pc.Color == Black {
+		startRank = 6
+	}
+
+	if pc.Type == Pawn && m.From.Rank() == startRank && (m.To.Rank() == startRank+2 || m.To.Rank() == startRank-2) {
+		next.epSquare = NewSquare(m.From.File(), (m.From.Rank()+m.To.Rank())/2)
+	}
+
+	next.board[m.From] = NoPiece
+	next.board[m.To] = pc
+	next.promoted[m.From] = false
+	next.promoted[m.To] = p.promoted[m.From]
+
+	if m.Promotion != NoPieceType {
+		next.board[m.To] = Piece{Type: m.Promotion, Color: pc.Color}
+		next.promoted[m.To] = true
+	}
+
+	if pc.Type == King {
+		next.castling[pc.Color] = [2]Square{NoSquare, NoSquare}
+	}
+
+	if captured.Type != NoPieceType {
+		switch p.variant {
+		case Crazyhouse:
+			if capturedPromoted {
+				captured.Type = Pawn
+			}
+
+			next.pockets[pc.Color][captured.Type]++
+		case Atomic:
+			next.explode(m.To)
+		}
+	}
+
+	for c := range next.castling {
+		for side, rook := range next.castling[c] {
+			if rook == m.From || rook != NoSquare && next.board[rook] != (Piece{Type: Rook, Color: Color(c)}) {
+				next.castling[c][side] = NoSquare
+			}
+		}
+	}
+}
+
+// explode removes the capturing piece and every piece but pawns around s.
+func (p *Position) explode(s Square) {
+	p.board[s] = NoPiece
+
+	for _, t := range kingTargets[s] {
+		if p.board[t].Type != Pawn {
+			p.board[t] = NoPiece
+		}
+	}
+}
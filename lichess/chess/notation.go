@@ -0,0 +1,246 @@
+package chess
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// UCI formats m in UCI notation, with castling as the king moving two
+// squares, except in Chess960 where the king captures its rook. Drops are
+// written as N@f3.
+func (p *Position) UCI(m Move) string {
+	if m.IsDrop() {
+		return strings.ToUpper(m.Drop.String()) + "@" + m.To.String()
+	}
+
+	to := m.To
+
+	if p.IsCastling(m) && p.variant != Chess960 {
+		side := queenSide
+		if m.To > m.From {
+			side = kingSide
+		}
+
+		to, _ = castlingDestinations(p.turn, side)
+	}
+
+	s := m.From.String() + to.String()
+	if m.Promotion != NoPieceType {
+		s += m.Promotion.String()
+	}
+
+	return s
+}
+
+// ParseUCI reads a move in UCI notation. Castling is accepted both as the king
+// moving two squares and as the king capturing its rook.
+func (p *Position) ParseUCI(s string) (Move, error) {
+	if len(s) == 4 && s[1] == '@' {
+		return p.parseDrop(s)
+	}
+
+	if len(s) != 4 && len(s) != 5 {
+		return Move{}, fmt.Errorf("chess: invalid UCI move %q", s)
+	}
+
+	from, err := ParseSquare(s[0:2])
+	if err != nil {
+		return Move{}, err
+	}
+
+	to, err := ParseSquare(s[2:4])
+	if err != nil {
+		return Move{}, err
+	}
+
+	m := Move{From: from, To: to}
+
+	if len(s) == 5 {
+		t, ok := parsePieceType(rune(s[4]))
+		if !ok || t == Pawn {
+			return Move{}, fmt.Errorf("chess: invalid UCI move %q", s)
+		}
+
+		m.Promotion = t
+	}
+
+	for _, legal := range p.LegalMoves() {
+		if legal == m || p.IsCastling(legal) && legal.From == m.From && p.UCI(legal) == s {
+			return legal, nil
+		}
+	}
+
+	return Move{}, fmt.Errorf("chess: illegal move %q in %v", s, p.FEN())
+}
+
+// parseDrop reads a crazyhouse drop such as N@f3, with or without the P of
+// pawn drops.
+func (p *Position) parseDrop(s string) (Move, error) {
+	at := strings.IndexByte(s, '@')
+
+	to, err := ParseSquare(s[at+1:])
+	if err != nil {
+		return Move{}, err
+	}
+
+	m := Move{To: to, Drop: Pawn}
+
+	if at == 1 {
+		t, ok := parsePieceType(toLower(rune(s[0])))
+		if !ok || t == King {
+			return Move{}, fmt.Errorf("chess: invalid drop %q", s)
+		}
+
+		m.Drop = t
+	}
+
+	if !p.IsLegal(m) {
+		return Move{}, fmt.Errorf("chess: illegal move %q in %v", s, p.FEN())
+	}
+
+	return m, nil
+}
+
+// SAN formats m in standard algebraic notation, including check and mate
+// suffixes.
+func (p *Position) SAN(m Move) string {
+	var sb strings.Builder
+
+	pc := p.board[m.From]
+
+	switch {
+	case m.IsDrop():
+		sb.WriteString(strings.ToUpper(m.Drop.String()) + "@" + m.To.String())
+	case p.IsCastling(m):
+		if m.To > m.From {
+			sb.WriteString("O-O")
+		} else {
+			sb.WriteString("O-O-O")
+		}
+	case pc.Type == Pawn:
+		if p.IsCapture(m) {
+			sb.WriteByte(byte('a' + m.From.File()))
+			sb.WriteByte('x')
+		}
+
+		sb.WriteString(m.To.String())
+
+		if m.Promotion != NoPieceType {
+			sb.WriteString("=" + strings.ToUpper(m.Promotion.String()))
+		}
+	default:
+		sb.WriteString(strings.ToUpper(pc.Type.String()))
+		sb.WriteString(p.disambiguation(m))
+
+		if p.IsCapture(m) {
+			sb.WriteByte('x')
+		}
+
+		sb.WriteString(m.To.String())
+	}
+
+	next := p.Play(m)
+
+	if next.InCheck() {
+		if len(next.LegalMoves()) == 0 {
+			sb.WriteByte('#')
+		} else {
+			sb.WriteByte('+')
+		}
+	}
+
+	return sb.String()
+}
+
+func (p *Position) disambiguation(m Move) string {
+	pc := p.board[m.From]
+	sameFile, sameRank, ambiguous := false, false, false
+
+	for _, other := range p.LegalMoves() {
+		if other.IsDrop() || other.To != m.To || other.From == m.From || p.board[other.From] != pc || p.IsCastling(other) {
+			continue
+		}
+
+		ambiguous = true
+		sameFile = sameFile || other.From.File() == m.From.File()
+		sameRank = sameRank || other.From.Rank() == m.From.Rank()
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return m.From.String()[:1]
+	case !sameRank:
+		return m.From.String()[1:]
+	default:
+		return m.From.String()
+	}
+}
+
+var sanRe = regexp.MustCompile(`^([NBRQK])?([a-h])?([1-8])?x?([a-h][1-8])(?:=?([NBRQK]))?$`)
+
+// ParseSAN reads a move in standard algebraic notation. Check, mate and
+// annotation suffixes are ignored.
+func (p *Position) ParseSAN(s string) (Move, error) {
+	san := strings.TrimRight(s, "+#!?")
+
+	switch san {
+	case "O-O", "0-0", "O-O-O", "0-0-0":
+		for _, m := range p.LegalMoves() {
+			if p.IsCastling(m) && (m.To > m.From) == (len(san) == 3) {
+				return m, nil
+			}
+		}
+
+		return Move{}, fmt.Errorf("chess: illegal move %q in %v", s, p.FEN())
+	}
+
+	if strings.Contains(san, "@") && len(san) <= 4 {
+		return p.parseDrop(san)
+	}
+
+	parts := sanRe.FindStringSubmatch(san)
+	if parts == nil {
+		return Move{}, fmt.Errorf("chess: invalid SAN move %q", s)
+	}
+
+	pieceType := Pawn
+	if parts[1] != "" {
+		pieceType, _ = parsePieceType(toLower(rune(parts[1][0])))
+	}
+
+	to, _ := ParseSquare(parts[4])
+
+	promotion := NoPieceType
+	if parts[5] != "" {
+		promotion, _ = parsePieceType(toLower(rune(parts[5][0])))
+	}
+
+	var (
+		found Move
+		count int
+	)
+
+	for _, m := range p.LegalMoves() {
+		switch {
+		case m.IsDrop() || m.To != to || p.board[m.From].Type != pieceType || p.IsCastling(m):
+		case m.Promotion != promotion:
+		case parts[2] != "" && m.From.String()[:1] != parts[2]:
+		case parts[3] != "" && m.From.String()[1:] != parts[3]:
+		default:
+			found = m
+			count++
+		}
+	}
+
+	switch count {
+	case 0:
+		return Move{}, fmt.Errorf("chess: illegal move %q in %v", s, p.FEN())
+	case 1:
+		return found, nil
+	default:
+		return Move{}, fmt.Errorf("chess: ambiguous move %q in %v", s, p.FEN())
+	}
+}
@@ -0,0 +1,63 @@
+package chess
+
+import "testing"
+
+func TestPosition_SAN(t *testing.T) {
+	tests := []struct {
+		fen string
+		uci string
+		san string
+	}{
+		{StartFEN, "g1f3", "Nf3"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e1g1", "O-O"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e1c1", "O-O-O"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e5f7", "Nxf7"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "d5e6", "dxe6"},
+		{"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", "e5f6", "exf6"},
+		{"7k/P7/8/8/8/8/8/K7 w - - 0 1", "a7a8q", "a8=Q+"},
+		{"7k/P7/8/8/8/8/8/K7 w - - 0 1", "a7a8n", "a8=N"},
+		{"6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", "a1a8", "Ra8#"},
+		{"7k/8/8/8/8/8/8/R4R1K w - - 0 1", "a1d1", "Rad1"},
+		{"7k/8/8/8/R7/8/8/R3K3 w - - 0 1", "a1a2", "R1a2"},
+		{"7k/8/8/8/Q1Q5/8/Q7/4K3 w - - 0 1", "a4b3", "Qa4b3"},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("ParseFEN returned an error: %v", err)
+		}
+
+		m, err := p.ParseUCI(tt.uci)
+		if err != nil {
+			t.Fatalf("ParseUCI(%q) returned an error: %v", tt.uci, err)
+		}
+
+		if got := p.SAN(m); got != tt.san {
+			t.Errorf("SAN(%v) = %q, want %q", tt.uci, got, tt.san)
+		}
+
+		parsed, err := p.ParseSAN(tt.san)
+		if err != nil {
+			t.Fatalf("ParseSAN(%q) returned an error: %v", tt.san, err)
+		}
+
+		if parsed != m {
+			t.Errorf("ParseSAN(%q) = %+v, want %+v", tt.san, parsed, m)
+		}
+
+		if got := p.UCI(parsed); got != tt.uci {
+			t.Errorf("UCI() = %q, want %q", got, tt.uci)
+		}
+	}
+}
+
+func TestPosition_ParseSAN_ExpectError(t *testing.T) {
+	p, _ := ParseFEN("7k/8/8/8/8/8/8/R4R1K w - - 0 1")
+
+	for _, san := range []string{"Rd1", "O-O", "Kh3", "e4", "Z9"} {
+		if _, err := p.ParseSAN(san); err == nil {
+			t.Errorf("ParseSAN(%q) should return an error", san)
+		}
+	}
+}
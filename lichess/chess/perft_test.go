@@ -0,0 +1,90 @@
+package chess
+
+import "testing"
+
+func perft(p *Position, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+
+	moves := p.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+
+	n := 0
+	for _, m := range moves {
+		n += perft(p.Play(m), depth-1)
+	}
+
+	return n
+}
+
+func TestPerft(t *testing.T) {
+	tests := []struct {
+		name   string
+		fen    string
+		counts []int
+	}{
+		{"start", StartFEN, []int{20, 400, 8902, 197281}},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039, 97862}},
+		{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812, 43238}},
+		{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467}},
+		{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486, 62379}},
+		{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []int{46, 2079, 89890}},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("%v: ParseFEN returned an error: %v", tt.name, err)
+		}
+
+		for i, want := range tt.counts {
+			if testing.Short() && want > 10000 {
+				continue
+			}
+
+			if got := perft(p, i+1); got != want {
+				t.Errorf("%v: perft(%d) = %d, want %d", tt.name, i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestPerftVariants(t *testing.T) {
+	tests := []struct {
+		variant Variant
+		fen     string
+		counts  []int
+	}{
+		{Chess960, "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9", []int{21, 528, 12189}},
+		{Chess960, "2nnrbkr/p1qppppp/8/1ppb4/6PP/3PP3/PPP2P2/BQNNRBKR w HEhe - 1 9", []int{21, 807, 18002}},
+		{Chess960, "b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w GE - 1 9", []int{20, 479, 10471}},
+		{Crazyhouse, Crazyhouse.StartFEN(), []int{20, 400, 8902, 197281}},
+		{Crazyhouse, "2k5/8/8/8/8/8/8/4K3[QRBNPqrbnp] w - - 0 1", []int{301}},
+		{Antichess, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1", []int{20, 400, 8067, 153299}},
+		{Atomic, StartFEN, []int{20, 400, 8902, 197326}},
+		{Horde, Horde.StartFEN(), []int{8, 128, 1274, 23310}},
+		{KingOfTheHill, StartFEN, []int{20, 400, 8902, 197281}},
+		{RacingKings, RacingKings.StartFEN(), []int{21, 421, 11264, 296242}},
+		{ThreeCheck, ThreeCheck.StartFEN(), []int{20, 400, 8902, 197281}},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseVariantFEN(tt.variant, tt.fen)
+		if err != nil {
+			t.Fatalf("%v: ParseVariantFEN returned an error: %v", tt.variant, err)
+		}
+
+		for i, want := range tt.counts {
+			if testing.Short() && want > 10000 {
+				continue
+			}
+
+			if got := perft(p, i+1); got != want {
+				t.Errorf("%v %q: perft(%d) = %d, want %d", tt.variant, tt.fen, i+1, got, want)
+			}
+		}
+	}
+}
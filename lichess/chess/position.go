@@ -0,0 +1,447 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+const (
+	kingSide  = 0
+	queenSide = 1
+)
+
+// Position is an immutable chess position; playing a move returns a new one.
+type Position struct {
+	variant Variant
+	board   [64]Piece
+	turn    Color
+	// castling holds the squares of the rooks that may still castle, indexed
+	// by color and side, so that Chess960 needs no special case.
+	castling  [2][2]Square
+	epSquare  Square
+	halfmoves int
+	fullmoves int
+	// pockets and promoted track crazyhouse drops: captured pieces by type,
+	// and promoted pieces that go back to the pocket as pawns.
+	pockets  [2][7]int
+	promoted [64]bool
+	// checks counts the checks given by each side in three-check.
+	checks [2]int
+}
+
+func StartingPosition() *Position {
+	p, _ := ParseFEN(StartFEN)
+
+	return p
+}
+
+// VariantStartingPosition returns the usual starting position of v.
+func VariantStartingPosition(v Variant) *Position {
+	p, _ := ParseVariantFEN(v, v.StartFEN())
+
+	return p
+}
+
+func (p *Position) Variant() Variant {
+	return p.variant
+}
+
+func (p *Position) Piece(s Square) Piece {
+	return p.board[s]
+}
+
+func (p *Position) Turn() Color {
+	return p.turn
+}
+
+// HalfmoveClock returns the number of plies since the last capture or pawn
+// move.
+func (p *Position) HalfmoveClock() int {
+	return p.halfmoves
+}
+
+func (p *Position) FullmoveNumber() int {
+	return p.fullmoves
+}
+
+// Ply returns the number of plies played since the start of the game.
+func (p *Position) Ply() int {
+	return (p.fullmoves-1)*2 + int(p.turn)
+}
+
+// Pocket returns how many pieces of type t c holds in crazyhouse.
+func (p *Position) Pocket(c Color, t PieceType) int {
+	return p.pockets[c][t]
+}
+
+// Checks returns the number of checks c gave in three-check.
+func (p *Position) Checks(c Color) int {
+	return p.checks[c]
+}
+
+func (p *Position) kingSquare(c Color) Square {
+	for s, pc := range p.board {
+		if pc.Type == King && pc.Color == c {
+			return Square(s)
+		}
+	}
+
+	return NoSquare
+}
+
+// ParseFEN reads a standard chess position in Forsyth-Edwards notation.
+// Castling rights may be given as KQkq or as the files of the castling rooks.
+func ParseFEN(fen string) (*Position, error) {
+	return ParseVariantFEN(Standard, fen)
+}
+
+// ParseVariantFEN reads a position of variant v. Crazyhouse pockets follow the
+// board in brackets, with promoted pieces marked by a tilde, and three-check
+// counters are read either as remaining checks ("3+3" before the move
+// counters) or as given checks ("+0+0" at the end).
+func ParseVariantFEN(v Variant, fen string) (*Position, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("chess: invalid FEN %q", fen)
+	}
+
+	p := &Position{
+		variant:   v,
+		castling:  [2][2]Square{{NoSquare, NoSquare}, {NoSquare, NoSquare}},
+		epSquare:  NoSquare,
+		fullmoves: 1,
+	}
+
+	board := fields[0]
+
+	if i := strings.IndexByte(board, '['); i >= 0 && strings.HasSuffix(board, "]") {
+		if err := p.parsePockets(board[i+1 : len(board)-1]); err != nil {
+			return nil, err
+		}
+
+		board = board[:i]
+	} else if ranks := strings.Split(board, "/"); len(ranks) == 9 {
+		if err := p.parsePockets(ranks[8]); err != nil {
+			return nil, err
+		}
+
+		board = strings.Join(ranks[:8], "/")
+	}
+
+	if err := p.parseBoard(board); err != nil {
+		return nil, err
+	}
+
+	fields = p.parseChecks(fields)
+
+	switch fields[1] {
+	case "w":
+		p.turn = White
+	case "b":
+		p.turn = Black
+	default:
+		return nil, fmt.Errorf("chess: invalid side to move %q", fields[1])
+	}
+
+	if err := p.parseCastling(fields[2]); err != nil {
+		return nil, err
+	}
+
+	if fields[3] != "-" {
+		s, err := ParseSquare(fields[3])
+		if err != nil {
+			return nil, err
+		}
+
+		p.epSquare = s
+	}
+
+	if len(fields) > 4 {
+		n, err := strconv.Atoi(fields[4])
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("chess: invalid halfmove clock %q", fields[4])
+		}
+
+		p.halfmoves = n
+	}
+
+	if len(fields) > 5 {
+		n, err := strconv.Atoi(fields[5])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("chess: invalid fullmove number %q", fields[5])
+		}
+
+		p.fullmoves = n
+	}
+
+	return p, nil
+}
+
+func (p *Position) parseBoard(s string) error {
+	ranks := strings.Split(s, "/")
+	if len(ranks) != 8 {
+		return fmt.Errorf("chess: invalid board %q", s)
+	}
+
+	for i, rank := range ranks {
+		file := 0
+
+		for _, r := range rank {
+			switch {
+			case r >= '1' && r <= '8':
+				file += int(r - '0')
+			case r == '~' && file > 0:
+				p.promoted[NewSquare(file-1, 7-i)] = true
+			default:
+				t, ok := parsePieceType(toLower(r))
+				if !ok || file > 7 {
+					return fmt.Errorf("chess: invalid board %q", s)
+				}
+
+				c := Black
+				if r != toLower(r) {
+					c = White
+				}
+
+				p.board[NewSquare(file, 7-i)] = Piece{Type: t, Color: c}
+				file++
+			}
+		}
+
+		if file != 8 {
+			return fmt.Errorf("chess: invalid board %q", s)
+		}
+	}
+
+	return nil
+}
+
+func (p *Position) parsePockets(s string) error {
+	for _, r := range s {
+		t, ok := parsePieceType(toLower(r))
+		if !ok || t == King {
+			return fmt.Errorf("chess: invalid pocket %q", s)
+		}
+
+		c := Black
+		if r != toLower(r) {
+			c = White
+		}
+
+		p.pockets[c][t]++
+	}
+
+	return nil
+}
+
+// parseChecks reads and removes the three-check counters from the fields.
+func (p *Position) parseChecks(fields []string) []string {
+	for i := 4; i < len(fields); i++ {
+		given := strings.HasPrefix(fields[i], "+")
+		counters := strings.Split(strings.TrimPrefix(fields[i], "+"), "+")
+
+		if len(counters) != 2 {
+			continue
+		}
+
+		white, errW := strconv.Atoi(counters[0])
+		black, errB := strconv.Atoi(counters[1])
+
+		if errW != nil || errB != nil {
+			continue
+		}
+
+		if given {
+			p.checks = [2]int{white, black}
+		} else {
+			p.checks = [2]int{3 - white, 3 - black}
+		}
+
+		return append(fields[:i:i], fields[i+1:]...)
+	}
+
+	return fields
+}
+
+func (p *Position) parseCastling(s string) error {
+	if s == "-" || p.variant == Antichess {
+		return nil
+	}
+
+	for _, r := range s {
+		c := White
+		if r == toLower(r) {
+			c = Black
+		}
+
+		backRank := 0
+		if c == Black {
+			backRank = 7
+		}
+
+		king := p.kingSquare(c)
+		if king == NoSquare || king.Rank() != backRank {
+			return fmt.Errorf("chess: invalid castling rights %q", s)
+		}
+
+		var rook Square
+
+		switch lr := toLower(r); {
+		case lr == 'k':
+			rook = p.outerRook(c, king, 1)
+		case lr == 'q':
+			rook = p.outerRook(c, king, -1)
+		case lr >= 'a' && lr <= 'h':
+			rook = NewSquare(int(lr-'a'), backRank)
+		default:
+			return fmt.Errorf("chess: invalid castling rights %q", s)
+		}
+
+		if rook == NoSquare || p.board[rook] != (Piece{Type: Rook, Color: c}) {
+			return fmt.Errorf("chess: invalid castling rights %q", s)
+		}
+
+		side := queenSide
+		if rook > king {
+			side = kingSide
+		}
+
+		p.castling[c][side] = rook
+	}
+
+	return nil
+}
+
+// outerRook finds the rook furthest from the king in direction dir.
+func (p *Position) outerRook(c Color, king Square, dir int) Square {
+	found := NoSquare
+
+	for s, ok := offset(king, dir, 0); ok; s, ok = offset(s, dir, 0) {
+		if p.board[s] == (Piece{Type: Rook, Color: c}) {
+			found = s
+		}
+	}
+
+	return found
+}
+
+func (p *Position) FEN() string {
+	var sb strings.Builder
+
+	for rank := 7; rank >= 0; rank-- {
+		empty := 0
+
+		for file := 0; file < 8; file++ {
+			pc := p.board[NewSquare(file, rank)]
+			if pc.Type == NoPieceType {
+				empty++
+				continue
+			}
+
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+
+			sb.WriteString(pc.String())
+
+			if p.promoted[NewSquare(file, rank)] {
+				sb.WriteByte('~')
+			}
+		}
+
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+
+		if rank > 0 {
+			sb.WriteByte('/')
+		}
+	}
+
+	if p.variant == Crazyhouse {
+		sb.WriteByte('[')
+
+		for _, c := range []Color{White, Black} {
+			for t := Queen; t >= Pawn; t-- {
+				sb.WriteString(strings.Repeat(Piece{Type: t, Color: c}.String(), p.pockets[c][t]))
+			}
+		}
+
+		sb.WriteByte(']')
+	}
+
+	turn := "w"
+	if p.turn == Black {
+		turn = "b"
+	}
+
+	fields := []string{sb.String(), turn, p.castlingFEN(), p.legalEnPassant().String()}
+
+	if p.variant == ThreeCheck {
+		fields = append(fields, fmt.Sprintf("%d+%d", 3-p.checks[White], 3-p.checks[Black]))
+	}
+
+	fields = append(fields, strconv.Itoa(p.halfmoves), strconv.Itoa(p.fullmoves))
+
+	return strings.Join(fields, " ")
+}
+
+func (p *Position) castlingFEN() string {
+	var sb strings.Builder
+
+	for _, c := range []Color{White, Black} {
+		king := p.kingSquare(c)
+
+		for side, dir := range []int{1, -1} {
+			rook := p.castling[c][side]
+			if rook == NoSquare {
+				continue
+			}
+
+			letter := rune('a' + rook.File())
+			if p.outerRook(c, king, dir) == rook {
+				letter = []rune("kq")[side]
+			}
+
+			if c == White {
+				letter -= 'a' - 'A'
+			}
+
+			sb.WriteRune(letter)
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "-"
+	}
+
+	return sb.String()
+}
+
+// legalEnPassant returns the en passant square only if a capture on it is
+// legal, the way Lichess writes FENs.
+func (p *Position) legalEnPassant() Square {
+	if p.epSquare == NoSquare {
+		return NoSquare
+	}
+
+	for _, m := range p.LegalMoves() {
+		if !m.IsDrop() && m.To == p.epSquare && p.board[m.From].Type == Pawn {
+			return p.epSquare
+		}
+	}
+
+	return NoSquare
+}
+
+func toLower(r rune) rune {
+	if r >= 'A' && r <= 'Z' {
+		return r + 'a' - 'A'
+	}
+
+	return r
+}
@@ -0,0 +1,49 @@
+package chess
+
+import "testing"
+
+func TestParseFEN_RoundTrip(t *testing.T) {
+	fens := []string{
+		StartFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R b Kq - 3 17",
+		"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3",
+		"8/8/8/8/8/8/8/k6K w - - 99 80",
+	}
+
+	for _, fen := range fens {
+		p, err := ParseFEN(fen)
+		if err != nil {
+			t.Fatalf("ParseFEN(%q) returned an error: %v", fen, err)
+		}
+
+		if got := p.FEN(); got != fen {
+			t.Errorf("FEN() = %q, want %q", got, fen)
+		}
+	}
+}
+
+func TestPosition_FENHidesUselessEnPassant(t *testing.T) {
+	p := StartingPosition()
+
+	m, _ := p.ParseUCI("e2e4")
+
+	if got, want := p.Play(m).FEN(), "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1"; got != want {
+		t.Errorf("FEN() = %q, want %q", got, want)
+	}
+}
+
+func TestParseFEN_ExpectError(t *testing.T) {
+	fens := []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBN1 w KQkq - 0 1",
+	}
+
+	for _, fen := range fens {
+		if _, err := ParseFEN(fen); err == nil {
+			t.Errorf("ParseFEN(%q) should return an error", fen)
+		}
+	}
+}
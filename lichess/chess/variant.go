@@ -0,0 +1,155 @@
+package chess
+
+import "fmt"
+
+// Variant selects the rules a Position follows.
+type Variant int
+
+const (
+	Standard Variant = iota
+	Chess960
+	Crazyhouse
+	Antichess
+	Atomic
+	Horde
+	KingOfTheHill
+	RacingKings
+	ThreeCheck
+)
+
+var variantKeys = map[string]Variant{
+	"standard":      Standard,
+	"fromPosition":  Standard,
+	"chess960":      Chess960,
+	"crazyhouse":    Crazyhouse,
+	"antichess":     Antichess,
+	"atomic":        Atomic,
+	"horde":         Horde,
+	"kingOfTheHill": KingOfTheHill,
+	"racingKings":   RacingKings,
+	"threeCheck":    ThreeCheck,
+}
+
+// ParseVariant maps a Lichess variant key to its rules. fromPosition games
+// follow the standard rules.
+func ParseVariant(key string) (Variant, error) {
+	v, ok := variantKeys[key]
+	if !ok {
+		return Standard, fmt.Errorf("chess: unknown variant %q", key)
+	}
+
+	return v, nil
+}
+
+func (v Variant) String() string {
+	for key, variant := range variantKeys {
+		if variant == v && key != "fromPosition" {
+			return key
+		}
+	}
+
+	return "unknown"
+}
+
+// StartFEN returns the usual starting position of the variant. Chess960 games
+// start from one of 960 positions, so they need their own FEN.
+func (v Variant) StartFEN() string {
+	switch v {
+	case Crazyhouse:
+		return "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[] w KQkq - 0 1"
+	case Horde:
+		return "rnbqkbnr/pppppppp/8/1PP2PP1/PPPPPPPP/PPPPPPPP/PPPPPPPP/PPPPPPPP w kq - 0 1"
+	case RacingKings:
+		return "8/8/8/8/8/8/krbnNBRK/qrbnNBRQ w - - 0 1"
+	case ThreeCheck:
+		return "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 3+3 0 1"
+	default:
+		return StartFEN
+	}
+}
+
+var centerSquares = []Square{D4, E4, D5, E5}
+
+// VariantOutcome reports whether a rule specific to the variant ended the
+// game: an exploded king in atomic, a king on the hill, a third check, a
+// captured horde, a king across the finish line, or an antichess player out
+// of pieces or moves. Unless the game is drawn, winner is set.
+func (p *Position) VariantOutcome() (ended bool, winner Color, draw bool) {
+	if ended, winner, draw = p.variantEnd(); ended {
+		return ended, winner, draw
+	}
+
+	if p.variant == Antichess && len(p.legalMoves()) == 0 {
+		return true, p.turn, false
+	}
+
+	return false, White, false
+}
+
+// variantEnd is VariantOutcome without the checks that need the legal moves of
+// the position, so that move generation can use it.
+func (p *Position) variantEnd() (ended bool, winner Color, draw bool) {
+	switch p.variant {
+	case Atomic:
+		for _, c := range []Color{White, Black} {
+			if p.kingSquare(c) == NoSquare {
+				return true, c.Other(), false
+			}
+		}
+	case KingOfTheHill:
+		for _, s := range centerSquares {
+			if pc := p.board[s]; pc.Type == King {
+				return true, pc.Color, false
+			}
+		}
+	case ThreeCheck:
+		for _, c := range []Color{White, Black} {
+			if p.checks[c] >= 3 {
+				return true, c, false
+			}
+		}
+	case Horde:
+		if !p.hasPieces(White) {
+			return true, Black, false
+		}
+	case RacingKings:
+		return p.racingKingsEnd()
+	}
+
+	return false, White, false
+}
+
+func (p *Position) racingKingsEnd() (ended bool, winner Color, draw bool) {
+	white := p.kingSquare(White).Rank() == 7
+	black := p.kingSquare(Black).Rank() == 7
+
+	switch {
+	case white && black:
+		return true, White, true
+	case black:
+		return true, Black, false
+	case white && p.turn == White:
+		return true, White, false
+	case white:
+		// Black moves last, so it may still draw by reaching the last rank.
+		for _, m := range p.legalMoves() {
+			if p.board[m.From].Type == King && m.To.Rank() == 7 {
+				return false, White, false
+			}
+		}
+
+		return true, White, false
+	}
+
+	return false, White, false
+}
+
+func (p *Position) hasPieces(c Color) bool {
+	for _, pc := range p.board {
+		if pc.Type != NoPieceType && pc.Color == c {
+			return true
+		}
+	}
+
+	return false
+}
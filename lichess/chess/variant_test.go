@@ -0,0 +1,152 @@
+package chess
+
+import "testing"
+
+func TestChess960_Castling(t *testing.T) {
+	p, err := ParseVariantFEN(Chess960, "rk5r/8/8/8/8/8/8/RK5R w KQkq - 0 1")
+	if err != nil {
+		t.Fatalf("ParseVariantFEN returned an error: %v", err)
+	}
+
+	m, err := p.ParseSAN("O-O")
+	if err != nil {
+		t.Fatalf("ParseSAN returned an error: %v", err)
+	}
+
+	if got, want := p.UCI(m), "b1h1"; got != want {
+		t.Errorf("UCI() = %v, want %v", got, want)
+	}
+
+	if got, want := p.Play(m).FEN(), "rk5r/8/8/8/8/8/8/R4RK1 b kq - 1 1"; got != want {
+		t.Errorf("FEN() = %v, want %v", got, want)
+	}
+
+	long, err := p.ParseUCI("b1a1")
+	if err != nil {
+		t.Fatalf("ParseUCI returned an error: %v", err)
+	}
+
+	if got, want := p.SAN(long), "O-O-O"; got != want {
+		t.Errorf("SAN() = %v, want %v", got, want)
+	}
+
+	if got, want := p.Play(long).FEN(), "rk5r/8/8/8/8/8/8/2KR3R b kq - 1 1"; got != want {
+		t.Errorf("FEN() = %v, want %v", got, want)
+	}
+}
+
+func TestCrazyhouse_Drops(t *testing.T) {
+	g := NewGame(VariantStartingPosition(Crazyhouse))
+
+	for _, san := range []string{"e4", "d5", "exd5", "Qxd5", "Nc3", "Qa5", "P@d5"} {
+		if err := g.PlaySAN(san); err != nil {
+			t.Fatalf("PlaySAN(%v) returned an error: %v", san, err)
+		}
+	}
+
+	p := g.Position()
+
+	if got, want := p.FEN(), "rnb1kbnr/ppp1pppp/8/q2P4/8/2N5/PPPP1PPP/R1BQKBNR[p] b KQkq - 3 4"; got != want {
+		t.Errorf("FEN() = %v, want %v", got, want)
+	}
+
+	m, err := p.ParseUCI("P@e3")
+	if err != nil {
+		t.Fatalf("ParseUCI returned an error: %v", err)
+	}
+
+	if got, want := p.SAN(m), "P@e3"; got != want {
+		t.Errorf("SAN() = %v, want %v", got, want)
+	}
+
+	if _, err := p.ParseSAN("N@e3"); err == nil {
+		t.Error("ParseSAN should reject a drop of a piece not in the pocket")
+	}
+
+	promoted, _ := ParseVariantFEN(Crazyhouse, "4k3/8/8/8/8/8/8/3Q~K2r[] b - - 0 1")
+	next := promoted.Play(Move{From: H1, To: D1})
+
+	if got, want := next.Pocket(Black, Pawn), 1; got != want {
+		t.Errorf("Pocket() = %v, want %v", got, want)
+	}
+}
+
+func TestAtomic_Explosion(t *testing.T) {
+	p, _ := ParseVariantFEN(Atomic, "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2")
+
+	m, _ := p.ParseSAN("exd5")
+	next := p.Play(m)
+
+	if got, want := next.FEN(), "rnbqkbnr/ppp1pppp/8/8/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 2"; got != want {
+		t.Errorf("FEN() = %v, want %v", got, want)
+	}
+
+	mate, _ := ParseVariantFEN(Atomic, "rnbqkbnr/pppppppp/8/8/8/5N2/PPPPPPPP/RNBQKB1R w KQkq - 0 1")
+	g := NewGame(mate)
+
+	for _, san := range []string{"Ng5", "e6", "Nxf7"} {
+		if err := g.PlaySAN(san); err != nil {
+			t.Fatalf("PlaySAN(%v) returned an error: %v", san, err)
+		}
+	}
+
+	if got, want := g.Result(), "1-0"; got != want {
+		t.Errorf("Result() = %v, want %v", got, want)
+	}
+}
+
+func TestAntichess_ForcedCaptures(t *testing.T) {
+	p, _ := ParseVariantFEN(Antichess, "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w - - 0 2")
+
+	moves := p.LegalMoves()
+	if len(moves) != 1 || p.SAN(moves[0]) != "exd5" {
+		t.Errorf("LegalMoves() = %v, want only exd5", moves)
+	}
+
+	empty, _ := ParseVariantFEN(Antichess, "8/8/8/8/8/8/8/7k w - - 0 1")
+	if got, want := NewGame(empty).Result(), "1-0"; got != want {
+		t.Errorf("Result() = %v, want %v", got, want)
+	}
+}
+
+func TestVariantOutcome(t *testing.T) {
+	tests := []struct {
+		variant Variant
+		fen     string
+		result  string
+	}{
+		{KingOfTheHill, "rnbq1bnr/pppppppp/8/8/3k4/8/PPPPPPPP/RNBQKBNR w KQ - 0 5", "0-1"},
+		{ThreeCheck, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 +3+0", "1-0"},
+		{ThreeCheck, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 3+1 0 1", "*"},
+		{Horde, "rnbqkbnr/pppppppp/8/8/8/8/8/8 w kq - 0 1", "0-1"},
+		{RacingKings, "K7/8/8/8/8/8/k7/8 w - - 0 1", "1-0"},
+		{RacingKings, "K7/8/8/8/8/8/8/7k b - - 0 1", "1-0"},
+		{RacingKings, "K7/1k6/8/8/8/8/8/8 b - - 0 1", "*"},
+		{RacingKings, "K1k5/8/8/8/8/8/8/8 w - - 0 1", "1/2-1/2"},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseVariantFEN(tt.variant, tt.fen)
+		if err != nil {
+			t.Fatalf("ParseVariantFEN(%q) returned an error: %v", tt.fen, err)
+		}
+
+		if got := NewGame(p).Result(); got != tt.result {
+			t.Errorf("%v %q: Result() = %v, want %v", tt.variant, tt.fen, got, tt.result)
+		}
+	}
+}
+
+func TestParseVariant(t *testing.T) {
+	if v, err := ParseVariant("fromPosition"); err != nil || v != Standard {
+		t.Errorf("ParseVariant(fromPosition) = %v, %v, want standard", v, err)
+	}
+
+	if v, _ := ParseVariant("kingOfTheHill"); v.String() != "kingOfTheHill" {
+		t.Errorf("String() = %v, want kingOfTheHill", v)
+	}
+
+	if _, err := ParseVariant("bughouse"); err == nil {
+		t.Error("ParseVariant should reject unknown variants")
+	}
+}
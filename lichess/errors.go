@@ -3,6 +3,7 @@ package lichess
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 type ErrorResponse struct {
@@ -27,3 +28,31 @@ func (r *RateLimitError) Error() string {
 		r.Response.Request.Method, r.Response.Request.URL,
 		r.Response.StatusCode, r.Message, r.Rate)
 }
+
+type MissingGamesError struct {
+	IDs []string
+}
+
+func (e *MissingGamesError) Error() string {
+	return fmt.Sprintf("games not found: %v", strings.Join(e.IDs, ", "))
+}
+
+// NoCloudEvalError is returned when the cloud has no evaluation of a position.
+type NoCloudEvalError struct {
+	Fen string
+}
+
+func (e *NoCloudEvalError) Error() string {
+	return fmt.Sprintf("no cloud evaluation for %v", e.Fen)
+}
+
+// MessageBlockedError is returned when the recipient of a private message does
+// not accept messages from the sender.
+type MessageBlockedError struct {
+	Username string
+	Message  string
+}
+
+func (e *MessageBlockedError) Error() string {
+	return fmt.Sprintf("%v does not accept messages: %v", e.Username, e.Message)
+}
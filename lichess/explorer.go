@@ -0,0 +1,189 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// ExplorerService talks to the opening explorer, which is served from its own
+// host rather than lichess.org.
+type ExplorerService service
+
+// ExplorerPosition selects the position to explore: Fen, or the standard
+// starting position when empty, followed by the UCI moves in Play.
+type ExplorerPosition struct {
+	Variant Variant  `url:"variant,omitempty"`
+	Fen     string   `url:"fen,omitempty"`
+	Play    []string `url:"play,omitempty"`
+	// Moves limits the number of moves returned.
+	Moves *int `url:"moves,omitempty"`
+}
+
+type MastersOptions struct {
+	ExplorerPosition
+	// Since and Until are years.
+	Since    int  `url:"since,omitempty"`
+	Until    int  `url:"until,omitempty"`
+	TopGames *int `url:"topGames,omitempty"`
+}
+
+type LichessExplorerOptions struct {
+	ExplorerPosition
+	Speeds []Speed `url:"speeds,omitempty"`
+	// Ratings are the lower bounds of the rating groups to include, e.g. 1600.
+	Ratings []int `url:"ratings,omitempty"`
+	// Since and Until are months formatted as YYYY-MM.
+	Since       string `url:"since,omitempty"`
+	Until       string `url:"until,omitempty"`
+	TopGames    *int   `url:"topGames,omitempty"`
+	RecentGames *int   `url:"recentGames,omitempty"`
+}
+
+type PlayerExplorerOptions struct {
+	ExplorerPosition
+	Color  Color    `url:"color,omitempty"`
+	Speeds []Speed  `url:"speeds,omitempty"`
+	Modes  []string `url:"modes,omitempty"`
+	// Since and Until are months formatted as YYYY-MM.
+	Since       string `url:"since,omitempty"`
+	Until       string `url:"until,omitempty"`
+	RecentGames *int   `url:"recentGames,omitempty"`
+}
+
+type ExplorerResult struct {
+	White       int             `json:"white"`
+	Draws       int             `json:"draws"`
+	Black       int             `json:"black"`
+	Moves       []*ExplorerMove `json:"moves"`
+	TopGames    []*ExplorerGame `json:"topGames,omitempty"`
+	RecentGames []*ExplorerGame `json:"recentGames,omitempty"`
+	Opening     *Opening        `json:"opening,omitempty"`
+	// QueuePosition is set by the player database while the games of the
+	// player are waiting to be indexed.
+	QueuePosition int `json:"queuePosition,omitempty"`
+}
+
+type ExplorerMove struct {
+	UCI           string `json:"uci"`
+	SAN           string `json:"san"`
+	AverageRating int    `json:"averageRating,omitempty"`
+	// AverageOpponentRating and Performance are only set by the player database.
+	AverageOpponentRating int           `json:"averageOpponentRating,omitempty"`
+	Performance           int           `json:"performance,omitempty"`
+	White                 int           `json:"white"`
+	Draws                 int           `json:"draws"`
+	Black                 int           `json:"black"`
+	Game                  *ExplorerGame `json:"game,omitempty"`
+}
+
+type ExplorerGame struct {
+	ID     string          `json:"id"`
+	UCI    string          `json:"uci,omitempty"`
+	Winner Color           `json:"winner,omitempty"`
+	Speed  Speed           `json:"speed,omitempty"`
+	Mode   string          `json:"mode,omitempty"`
+	White  *ExplorerPlayer `json:"white"`
+	Black  *ExplorerPlayer `json:"black"`
+	Year   int             `json:"year"`
+	Month  string          `json:"month,omitempty"`
+}
+
+type ExplorerPlayer struct {
+	Name   string `json:"name"`
+	Rating int    `json:"rating"`
+}
+
+// Masters queries the database of over the board games between masters.
+func (s *ExplorerService) Masters(ctx context.Context, opts MastersOptions) (*ExplorerResult, *Response, error) {
+	return s.query(ctx, "masters", opts)
+}
+
+// Lichess queries the database of rated games played on lichess.
+func (s *ExplorerService) Lichess(
+	ctx context.Context, opts LichessExplorerOptions,
+) (*ExplorerResult, *Response, error) {
+	return s.query(ctx, "lichess", opts)
+}
+
+func (s *ExplorerService) query(
+	ctx context.Context, path string, opts interface{},
+) (*ExplorerResult, *Response, error) {
+	u, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.newRequest(s.client.explorerURL, "GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	result := new(ExplorerResult)
+	resp, err := s.client.Do(ctx, req, result)
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return result, resp, nil
+}
+
+// Player queries the games of a single player. The player's games are indexed
+// on demand, so the explorer streams progressively more complete results
+// until indexing is done: the last result sent is the final one.
+func (s *ExplorerService) Player(
+	ctx context.Context, player string, opts PlayerExplorerOptions,
+) (<-chan *ExplorerResult, <-chan error) {
+	resCh := make(chan *ExplorerResult)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(resCh)
+			close(errCh)
+		}()
+
+		params := struct {
+			Player string `url:"player"`
+			PlayerExplorerOptions
+		}{player, opts}
+
+		u, err := addOptions("player", params)
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req, err := s.client.newRequest(s.client.explorerURL, "GET", u, nil)
+
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+		err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+			result := new(ExplorerResult)
+			if err := json.Unmarshal(line, result); err != nil {
+				return err
+			}
+
+			select {
+			case resCh <- result:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return resCh, errCh
+}
@@ -0,0 +1,194 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExplorerService_Masters(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/masters", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.RawQuery, "play=e2e4%2Ce7e5&since=1990&topGames=1"; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "white": 1212,
+		  "draws": 1591,
+		  "black": 779,
+		  "moves": [
+		    {
+		      "uci": "g1f3",
+		      "san": "Nf3",
+		      "averageRating": 2421,
+		      "white": 1063,
+		      "draws": 1346,
+		      "black": 679,
+		      "game": null
+		    }
+		  ],
+		  "topGames": [
+		    {
+		      "uci": "g1f3",
+		      "id": "xWD0uvFN",
+		      "winner": "white",
+		      "black": {"name": "Carlsen, M.", "rating": 2882},
+		      "white": {"name": "Caruana, F.", "rating": 2805},
+		      "year": 2014,
+		      "month": "2014-06"
+		    }
+		  ],
+		  "opening": {"eco": "C20", "name": "King's Pawn Game"}
+		}
+		`)
+	})
+
+	opts := MastersOptions{
+		ExplorerPosition: ExplorerPosition{Play: []string{"e2e4", "e7e5"}},
+		Since:            1990,
+		TopGames:         Int(1),
+	}
+	result, _, err := client.Explorer.Masters(context.Background(), opts)
+
+	if err != nil {
+		t.Fatalf("Explorer.Masters returned error: %v", err)
+	}
+
+	want := &ExplorerResult{
+		White: 1212,
+		Draws: 1591,
+		Black: 779,
+		Moves: []*ExplorerMove{{UCI: "g1f3", SAN: "Nf3", AverageRating: 2421, White: 1063, Draws: 1346, Black: 679}},
+		TopGames: []*ExplorerGame{{
+			ID:     "xWD0uvFN",
+			UCI:    "g1f3",
+			Winner: White,
+			White:  &ExplorerPlayer{Name: "Caruana, F.", Rating: 2805},
+			Black:  &ExplorerPlayer{Name: "Carlsen, M.", Rating: 2882},
+			Year:   2014,
+			Month:  "2014-06",
+		}},
+		Opening: &Opening{Eco: "C20", Name: "King's Pawn Game"},
+	}
+
+	if diff := cmp.Diff(result, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestExplorerService_Lichess(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/lichess", func(w http.ResponseWriter, r *http.Request) {
+		want := "fen=8%2F8%2F8%2F8%2F8%2F8%2F8%2F8+w+-+-+0+1&ratings=2200%2C2500&since=2020-01" +
+			"&speeds=blitz%2Crapid&variant=atomic"
+		if got := r.URL.RawQuery; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "white": 10,
+		  "draws": 1,
+		  "black": 5,
+		  "moves": [],
+		  "recentGames": [
+		    {
+		      "id": "abcdefgh",
+		      "winner": null,
+		      "speed": "blitz",
+		      "mode": "rated",
+		      "white": {"name": "a", "rating": 2300},
+		      "black": {"name": "b", "rating": 2250},
+		      "year": 2021,
+		      "month": "2021-02"
+		    }
+		  ],
+		  "opening": null
+		}
+		`)
+	})
+
+	opts := LichessExplorerOptions{
+		ExplorerPosition: ExplorerPosition{Variant: VariantAtomic, Fen: "8/8/8/8/8/8/8/8 w - - 0 1"},
+		Speeds:           []Speed{SpeedBlitz, SpeedRapid},
+		Ratings:          []int{2200, 2500},
+		Since:            "2020-01",
+	}
+	result, _, err := client.Explorer.Lichess(context.Background(), opts)
+
+	if err != nil {
+		t.Fatalf("Explorer.Lichess returned error: %v", err)
+	}
+
+	if len(result.RecentGames) != 1 || result.RecentGames[0].Speed != SpeedBlitz || result.Opening != nil {
+		t.Errorf("Explorer.Lichess returned %+v", result)
+	}
+}
+
+func TestExplorerService_Player(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/player", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("player"), "thibault"; got != want {
+			t.Errorf("player is %q, want %q", got, want)
+		}
+
+		if got, want := r.URL.Query().Get("color"), "white"; got != want {
+			t.Errorf("color is %q, want %q", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `{"white":0,"draws":0,"black":0,"moves":[],"recentGames":[],"opening":null,"queuePosition":2}
+		{
+		  "white": 3,
+		  "draws": 0,
+		  "black": 1,
+		  "moves": [
+		    {
+		      "uci": "e2e4",
+		      "san": "e4",
+		      "averageOpponentRating": 1800,
+		      "performance": 2050,
+		      "white": 3,
+		      "draws": 0,
+		      "black": 1,
+		      "game": null
+		    }
+		  ],
+		  "recentGames": [],
+		  "opening": null
+		}
+`)
+	})
+
+	resCh, errCh := client.Explorer.Player(context.Background(), "thibault", PlayerExplorerOptions{Color: White})
+
+	var results []*ExplorerResult
+	for res := range resCh {
+		results = append(results, res)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Explorer.Player returned error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Explorer.Player returned %d results, want 2", len(results))
+	}
+
+	if results[0].QueuePosition != 2 || results[1].Moves[0].Performance != 2050 {
+		t.Errorf("Explorer.Player returned %+v %+v", results[0], results[1])
+	}
+}
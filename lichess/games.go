@@ -1,71 +1,321 @@
 package lichess
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/VMAnalytic/lichess-api-client/lichess/chess"
+	"github.com/VMAnalytic/lichess-api-client/lichess/pgn"
 	"github.com/pkg/errors"
 )
 
 type GamesService service
 
+type Color string
+
+const (
+	White Color = "white"
+	Black Color = "black"
+)
+
+func (c Color) opposite() Color {
+	if c == White {
+		return Black
+	}
+
+	return White
+}
+
+type Variant string
+
+const (
+	VariantStandard      Variant = "standard"
+	VariantChess960      Variant = "chess960"
+	VariantCrazyhouse    Variant = "crazyhouse"
+	VariantAntichess     Variant = "antichess"
+	VariantAtomic        Variant = "atomic"
+	VariantHorde         Variant = "horde"
+	VariantKingOfTheHill Variant = "kingOfTheHill"
+	VariantRacingKings   Variant = "racingKings"
+	VariantThreeCheck    Variant = "threeCheck"
+	VariantFromPosition  Variant = "fromPosition"
+)
+
+type Speed string
+
+const (
+	SpeedUltraBullet    Speed = "ultraBullet"
+	SpeedBullet         Speed = "bullet"
+	SpeedBlitz          Speed = "blitz"
+	SpeedRapid          Speed = "rapid"
+	SpeedClassical      Speed = "classical"
+	SpeedCorrespondence Speed = "correspondence"
+)
+
+type GameStatus string
+
+const (
+	StatusCreated       GameStatus = "created"
+	StatusStarted       GameStatus = "started"
+	StatusAborted       GameStatus = "aborted"
+	StatusMate          GameStatus = "mate"
+	StatusResign        GameStatus = "resign"
+	StatusStalemate     GameStatus = "stalemate"
+	StatusTimeout       GameStatus = "timeout"
+	StatusDraw          GameStatus = "draw"
+	StatusOutOfTime     GameStatus = "outoftime"
+	StatusCheat         GameStatus = "cheat"
+	StatusNoStart       GameStatus = "noStart"
+	StatusUnknownFinish GameStatus = "unknownFinish"
+	StatusVariantEnd    GameStatus = "variantEnd"
+)
+
 type Game struct {
-	ID         string `json:"id"`
-	Rated      bool   `json:"rated"`
-	Variant    string `json:"variant"`
-	Speed      string `json:"speed"`
-	Perf       string `json:"perf"`
-	CreatedAt  int64  `json:"createdAt"`
-	LastMoveAt int64  `json:"lastMoveAt"`
-	Status     string `json:"status"`
-	Players    struct {
-		White struct {
-			User struct {
-				Name string `json:"name"`
-				ID   string `json:"id"`
-			} `json:"user"`
-			Rating     int       `json:"rating"`
-			RatingDiff int       `json:"ratingDiff"`
-			Analysis   *Analysis `json:"analysis"`
-		} `json:"white"`
-		Black struct {
-			User struct {
-				Name string `json:"name"`
-				ID   string `json:"id"`
-			} `json:"user"`
-			Rating     int       `json:"rating"`
-			RatingDiff int       `json:"ratingDiff"`
-			Analysis   *Analysis `json:"analysis"`
-		} `json:"black"`
-	} `json:"players"`
-	Winner  string `json:"winner"`
-	Moves   string `json:"moves"`
-	Pgn     string `json:"pgn"`
-	Opening struct {
-		Eco  string `json:"eco"`
-		Name string `json:"name"`
-		Ply  int64  `json:"ply"`
-	} `json:"opening"`
-	Clock struct {
-		Initial   int `json:"initial"`
-		Increment int `json:"increment"`
-		TotalTime int `json:"totalTime"`
-	} `json:"clock"`
+	ID          string          `json:"id"`
+	Rated       bool            `json:"rated"`
+	Variant     Variant         `json:"variant"`
+	Speed       Speed           `json:"speed"`
+	Perf        string          `json:"perf"`
+	CreatedAt   Timestamp       `json:"createdAt"`
+	LastMoveAt  Timestamp       `json:"lastMoveAt"`
+	Status      GameStatus      `json:"status"`
+	Source      string          `json:"source,omitempty"`
+	Players     GamePlayers     `json:"players"`
+	Winner      Color           `json:"winner,omitempty"`
+	InitialFen  string          `json:"initialFen,omitempty"`
+	LastFen     string          `json:"lastFen,omitempty"`
+	Moves       string          `json:"moves,omitempty"`
+	Pgn         string          `json:"pgn,omitempty"`
+	Opening     *Opening        `json:"opening,omitempty"`
+	Clock       *Clock          `json:"clock,omitempty"`
+	DaysPerTurn int             `json:"daysPerTurn,omitempty"`
+	Clocks      []int           `json:"clocks,omitempty"`
+	Analysis    []*MoveAnalysis `json:"analysis,omitempty"`
+	Division    *Division       `json:"division,omitempty"`
+	Tournament  string          `json:"tournament,omitempty"`
+	Swiss       string          `json:"swiss,omitempty"`
+}
+
+// ParsePGN parses the Pgn field, which is only set when the game was exported
+// with PgnInJSON.
+func (g *Game) ParsePGN() (*pgn.Game, error) {
+	if g.Pgn == "" {
+		return nil, errors.New("game has no PGN")
+	}
+
+	return pgn.NewDecoder(strings.NewReader(g.Pgn)).Decode()
+}
+
+// Replay plays the moves of the game from its initial position under the
+// rules of its variant, so that every position of the game can be inspected.
+func (g *Game) Replay() (*chess.Game, error) {
+	variant := chess.Standard
+
+	if g.Variant != "" {
+		var err error
+
+		if variant, err = chess.ParseVariant(string(g.Variant)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	fen := g.InitialFen
+	if fen == "" {
+		fen = variant.StartFEN()
+	}
+
+	start, err := chess.ParseVariantFEN(variant, fen)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return chess.Replay(start, g.Moves)
+}
+
+// TimelinePly is a single move of a game with the clock and analysis data
+// recorded for it.
+type TimelinePly struct {
+	// Ply counts from 1 for the first move of the game.
+	Ply   int
+	Color Color
+	SAN   string
+	// Clock is the time left after the move and Spent the time the move took,
+	// increment deducted. Both are only known when HasClock is set.
+	HasClock bool
+	Clock    time.Duration
+	Spent    time.Duration
+	// Analysis is the evaluation after the move, if the game was analysed.
+	Analysis *MoveAnalysis
+}
+
+// Timeline zips the moves of the game with its clocks and computer analysis,
+// which need the Clocks and Evals export options.
+func (g *Game) Timeline() []*TimelinePly {
+	moves := strings.Fields(g.Moves)
+	timeline := make([]*TimelinePly, len(moves))
+
+	first := White
+	if fields := strings.Fields(g.InitialFen); len(fields) > 1 && fields[1] == "b" {
+		first = Black
+	}
+
+	var increment time.Duration
+
+	if g.Clock != nil {
+		increment = time.Duration(g.Clock.Increment) * time.Second
+	}
+
+	for i, san := range moves {
+		ply := &TimelinePly{Ply: i + 1, Color: first, SAN: san}
+
+		if i%2 == 1 {
+			ply.Color = first.opposite()
+		}
+
+		if i < len(g.Clocks) {
+			ply.HasClock = true
+			ply.Clock = centiseconds(g.Clocks[i])
+
+			// The clocks only start running after each side's first move.
+			if i >= 2 {
+				if spent := centiseconds(g.Clocks[i-2]) - ply.Clock + increment; spent > 0 {
+					ply.Spent = spent
+				}
+			}
+		}
+
+		if i < len(g.Analysis) {
+			ply.Analysis = g.Analysis[i]
+		}
+
+		timeline[i] = ply
+	}
+
+	return timeline
+}
+
+func centiseconds(cs int) time.Duration {
+	return time.Duration(cs) * 10 * time.Millisecond
+}
+
+type GamePlayers struct {
+	White *GamePlayer `json:"white"`
+	Black *GamePlayer `json:"black"`
+}
+
+type GamePlayer struct {
+	User *LightUser `json:"user,omitempty"`
+	// Name is set instead of User for anonymous players.
+	Name        string    `json:"name,omitempty"`
+	Rating      int       `json:"rating,omitempty"`
+	RatingDiff  int       `json:"ratingDiff,omitempty"`
+	Provisional bool      `json:"provisional,omitempty"`
+	AILevel     int       `json:"aiLevel,omitempty"`
+	Team        string    `json:"team,omitempty"`
+	Analysis    *Analysis `json:"analysis,omitempty"`
+}
+
+type LightUser struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Title  string `json:"title,omitempty"`
+	Patron bool   `json:"patron,omitempty"`
+}
+
+type Opening struct {
+	Eco  string `json:"eco"`
+	Name string `json:"name"`
+	Ply  int    `json:"ply"`
+}
+
+type Clock struct {
+	Initial   int `json:"initial"`
+	Increment int `json:"increment"`
+	TotalTime int `json:"totalTime"`
 }
 
 type Analysis struct {
-	Inaccuracy uint8 `json:"inaccuracy"`
-	Mistake    uint8 `json:"mistake"`
-	Blunder    uint8 `json:"blunder"`
-	ACPL       uint8 `json:"acpl"`
+	Inaccuracy int `json:"inaccuracy"`
+	Mistake    int `json:"mistake"`
+	Blunder    int `json:"blunder"`
+	ACPL       int `json:"acpl"`
+}
+
+// MoveAnalysis is the computer evaluation after a ply. Either Eval (in
+// centipawns) or Mate is set.
+type MoveAnalysis struct {
+	Eval      *int      `json:"eval,omitempty"`
+	Mate      *int      `json:"mate,omitempty"`
+	Best      string    `json:"best,omitempty"`
+	Variation string    `json:"variation,omitempty"`
+	Judgment  *Judgment `json:"judgment,omitempty"`
+}
+
+type Judgment struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+}
+
+// Division holds the plies at which the middlegame and endgame start.
+type Division struct {
+	Middle int `json:"middle,omitempty"`
+	End    int `json:"end,omitempty"`
+}
+
+type PerfType string
+
+const (
+	PerfUltraBullet    PerfType = "ultraBullet"
+	PerfBullet         PerfType = "bullet"
+	PerfBlitz          PerfType = "blitz"
+	PerfRapid          PerfType = "rapid"
+	PerfClassical      PerfType = "classical"
+	PerfCorrespondence PerfType = "correspondence"
+	PerfChess960       PerfType = "chess960"
+	PerfCrazyhouse     PerfType = "crazyhouse"
+	PerfAntichess      PerfType = "antichess"
+	PerfAtomic         PerfType = "atomic"
+	PerfHorde          PerfType = "horde"
+	PerfKingOfTheHill  PerfType = "kingOfTheHill"
+	PerfRacingKings    PerfType = "racingKings"
+	PerfThreeCheck     PerfType = "threeCheck"
+)
+
+type ExportOptions struct {
+	Moves     *bool `url:"moves,omitempty"`
+	PgnInJSON *bool `url:"pgnInJson,omitempty"`
+	Tags      *bool `url:"tags,omitempty"`
+	Clocks    *bool `url:"clocks,omitempty"`
+	Evals     *bool `url:"evals,omitempty"`
+	Opening   *bool `url:"opening,omitempty"`
 }
 
 type ListOptions struct {
-	Since int64 `url:"since,omitempty"`
+	Since    time.Time  `url:"since,omitempty"`
+	Until    time.Time  `url:"until,omitempty"`
+	Max      int        `url:"max,omitempty"`
+	Vs       string     `url:"vs,omitempty"`
+	Rated    *bool      `url:"rated,omitempty"`
+	PerfType []PerfType `url:"perfType,omitempty"`
+	Color    Color      `url:"color,omitempty"`
+	Analysed *bool      `url:"analysed,omitempty"`
+	Ongoing  bool       `url:"ongoing,omitempty"`
+	Finished *bool      `url:"finished,omitempty"`
+	ExportOptions
 }
 
 func (s *GamesService) Get(ctx context.Context, ID string) (*Game, *Response, error) {
-	u := fmt.Sprintf("/game/export/%v?pgnInJson=true", ID)
+	u, err := addOptions(fmt.Sprintf("game/export/%v", ID), ExportOptions{PgnInJSON: Bool(true)})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
@@ -83,8 +333,103 @@ func (s *GamesService) Get(ctx context.Context, ID string) (*Game, *Response, er
 	return game, resp, nil
 }
 
+func (s *GamesService) GetCurrent(ctx context.Context, username string, opts ExportOptions) (*Game, *Response, error) {
+	if opts.PgnInJSON == nil {
+		opts.PgnInJSON = Bool(true)
+	}
+
+	u, err := addOptions(fmt.Sprintf("api/user/%v/current-game", username), opts)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	game := new(Game)
+
+	resp, err := s.client.Do(ctx, req, game)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return game, resp, nil
+}
+
+type OngoingGame struct {
+	GameID   string `json:"gameId"`
+	FullID   string `json:"fullId"`
+	Color    Color  `json:"color"`
+	Fen      string `json:"fen"`
+	HasMoved bool   `json:"hasMoved"`
+	IsMyTurn bool   `json:"isMyTurn"`
+	LastMove string `json:"lastMove"`
+	Opponent struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Rating   int    `json:"rating"`
+		AI       int    `json:"ai"`
+	} `json:"opponent"`
+	Perf        string `json:"perf"`
+	Rated       bool   `json:"rated"`
+	SecondsLeft int    `json:"secondsLeft"`
+	Source      string `json:"source"`
+	Speed       Speed  `json:"speed"`
+	Variant     struct {
+		Key  Variant `json:"key"`
+		Name string  `json:"name"`
+	} `json:"variant"`
+}
+
+// Playing returns the ongoing games of the authenticated user, at most nb of
+// them when nb is positive.
+func (s *GamesService) Playing(ctx context.Context, nb int) ([]*OngoingGame, *Response, error) {
+	opts := struct {
+		Nb int `url:"nb,omitempty"`
+	}{nb}
+
+	u, err := addOptions("api/account/playing", opts)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	var playing struct {
+		NowPlaying []*OngoingGame `json:"nowPlaying"`
+	}
+
+	resp, err := s.client.Do(ctx, req, &playing)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return playing.NowPlaying, resp, nil
+}
+
 func (s *GamesService) List(ctx context.Context, username string, opts ListOptions) ([]*Game, *Response, error) {
-	u := fmt.Sprintf("/api/games/user/%v?pgnInJson=true&since=%v&opening=true&cloacks=true", username, opts.Since)
+	if opts.PgnInJSON == nil {
+		opts.PgnInJSON = Bool(true)
+	}
+
+	if opts.Opening == nil {
+		opts.Opening = Bool(true)
+	}
+
+	u, err := addOptions(fmt.Sprintf("api/games/user/%v", username), opts)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
@@ -106,50 +451,620 @@ func (s *GamesService) List(ctx context.Context, username string, opts ListOptio
 
 func (s *GamesService) All(ctx context.Context, username string) (<-chan *Game, <-chan error) {
 	max := 50
-	since := 0
 	gch := make(chan *Game, max)
-	errCh := make(chan error)
-
-	defer func() {
-		close(gch)
-		close(errCh)
-	}()
+	errCh := make(chan error, 1)
 
 	go func() {
+		defer func() {
+			close(gch)
+			close(errCh)
+		}()
+
+		opts := ListOptions{Max: max}
+
 		for {
-			u := fmt.Sprintf("/api/games/user/%v?pgnInJson=true&since=%v&max=%v", username, since, max)
-			req, err := s.client.NewRequest("GET", u, nil)
+			games, _, err := s.List(ctx, username, opts)
 
 			if err != nil {
 				errCh <- err
-				break
+				return
 			}
 
-			req.Header.Set("Accept", mediaTypeEnableNDJson)
+			for _, g := range games {
+				select {
+				case gch <- g:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+			if len(games) < max {
+				return
+			}
 
-			var games []*Game
+			// Games come newest first, so the next page ends right before the
+			// oldest game of this one.
+			opts.Until = games[len(games)-1].CreatedAt.Add(-time.Millisecond)
+		}
+	}()
 
-			_, err = s.client.Do(ctx, req, &games)
+	return gch, errCh
+}
 
+// ExportGamePGN writes the PGN of a game into w.
+func (s *GamesService) ExportGamePGN(
+	ctx context.Context, ID string, opts ExportOptions, w io.Writer,
+) (*Response, error) {
+	u, err := addOptions(fmt.Sprintf("game/export/%v", ID), opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.exportPGN(ctx, u, w)
+}
+
+func (s *GamesService) GetPGN(ctx context.Context, ID string, opts ExportOptions) (*pgn.Game, *Response, error) {
+	var buf bytes.Buffer
+
+	resp, err := s.ExportGamePGN(ctx, ID, opts, &buf)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return parseSinglePGN(buf.String(), resp)
+}
+
+// ExportCurrentPGN writes the PGN of the ongoing or last game of a user into w.
+func (s *GamesService) ExportCurrentPGN(
+	ctx context.Context, username string, opts ExportOptions, w io.Writer,
+) (*Response, error) {
+	u, err := addOptions(fmt.Sprintf("api/user/%v/current-game", username), opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.exportPGN(ctx, u, w)
+}
+
+func (s *GamesService) GetCurrentPGN(
+	ctx context.Context, username string, opts ExportOptions,
+) (*pgn.Game, *Response, error) {
+	var buf bytes.Buffer
+
+	resp, err := s.ExportCurrentPGN(ctx, username, opts, &buf)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return parseSinglePGN(buf.String(), resp)
+}
+
+func parseSinglePGN(s string, resp *Response) (*pgn.Game, *Response, error) {
+	game, err := pgn.NewDecoder(strings.NewReader(s)).Decode()
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return game, resp, nil
+}
+
+// ExportPGN streams the PGN of the games of a user into w as it arrives, so
+// that large archives never have to be held in memory.
+func (s *GamesService) ExportPGN(
+	ctx context.Context, username string, opts ListOptions, w io.Writer,
+) (*Response, error) {
+	u, err := addOptions(fmt.Sprintf("api/games/user/%v", username), opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.exportPGN(ctx, u, w)
+}
+
+func (s *GamesService) ListPGN(ctx context.Context, username string, opts ListOptions) ([]*pgn.Game, *Response, error) {
+	var buf bytes.Buffer
+
+	resp, err := s.ExportPGN(ctx, username, opts, &buf)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	games, err := pgn.Parse(buf.String())
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return games, resp, nil
+}
+
+func (s *GamesService) exportPGN(ctx context.Context, u string, w io.Writer) (*Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypePGN)
+
+	return s.client.streamTo(ctx, req, w)
+}
+
+type StreamedGame struct {
+	ID      string `json:"id"`
+	Variant struct {
+		Key   Variant `json:"key"`
+		Name  string  `json:"name"`
+		Short string  `json:"short"`
+	} `json:"variant"`
+	Speed         Speed  `json:"speed"`
+	Perf          string `json:"perf"`
+	Rated         bool   `json:"rated"`
+	InitialFen    string `json:"initialFen"`
+	Fen           string `json:"fen"`
+	Player        Color  `json:"player"`
+	Turns         int    `json:"turns"`
+	StartedAtTurn int    `json:"startedAtTurn"`
+	Source        string `json:"source"`
+	Status        struct {
+		ID   int        `json:"id"`
+		Name GameStatus `json:"name"`
+	} `json:"status"`
+	CreatedAt Timestamp   `json:"createdAt"`
+	LastMove  string      `json:"lastMove"`
+	Winner    Color       `json:"winner"`
+	Players   GamePlayers `json:"players"`
+}
+
+type StreamedMove struct {
+	Fen        string `json:"fen"`
+	LastMove   string `json:"lm"`
+	WhiteClock int    `json:"wc"`
+	BlackClock int    `json:"bc"`
+}
+
+// GameStreamEvent holds exactly one of Game (sent first and again when the
+// game ends) or Move.
+type GameStreamEvent struct {
+	Game *StreamedGame
+	Move *StreamedMove
+}
+
+func (s *GamesService) StreamMoves(ctx context.Context, ID string) (<-chan *GameStreamEvent, <-chan error) {
+	evCh := make(chan *GameStreamEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(evCh)
+			close(errCh)
+		}()
+
+		u := fmt.Sprintf("api/stream/game/%v", ID)
+		req, err := s.client.NewRequest("GET", u, nil)
+
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+		err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+			var probe struct {
+				ID string `json:"id"`
+			}
+
+			if err := json.Unmarshal(line, &probe); err != nil {
+				return err
+			}
+
+			ev := new(GameStreamEvent)
+
+			if probe.ID != "" {
+				ev.Game = new(StreamedGame)
+				if err := json.Unmarshal(line, ev.Game); err != nil {
+					return err
+				}
+			} else {
+				ev.Move = new(StreamedMove)
+				if err := json.Unmarshal(line, ev.Move); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case evCh <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return evCh, errCh
+}
+
+type GamesStreamEventType string
+
+const (
+	GameStarted  GamesStreamEventType = "gameStart"
+	GameFinished GamesStreamEventType = "gameFinish"
+	GameMoved    GamesStreamEventType = "move"
+)
+
+type StreamedGameStatus struct {
+	ID         string      `json:"id"`
+	Rated      bool        `json:"rated"`
+	Variant    Variant     `json:"variant"`
+	Speed      Speed       `json:"speed"`
+	Perf       string      `json:"perf"`
+	CreatedAt  Timestamp   `json:"createdAt"`
+	Status     int         `json:"status"`
+	StatusName GameStatus  `json:"statusName"`
+	Winner     Color       `json:"winner"`
+	Clock      *Clock      `json:"clock"`
+	Players    GamePlayers `json:"players"`
+}
+
+// GamesStreamEvent carries Game for GameStarted and GameFinished events and
+// Move for GameMoved events.
+type GamesStreamEvent struct {
+	Type   GamesStreamEventType
+	GameID string
+	Game   *StreamedGameStatus
+	Move   *StreamedMove
+}
+
+func (s *GamesService) StreamByUsers(
+	ctx context.Context, userIDs []string, withCurrentGames bool,
+) (<-chan *GamesStreamEvent, <-chan error) {
+	opts := struct {
+		WithCurrentGames bool `url:"withCurrentGames,omitempty"`
+	}{withCurrentGames}
+
+	u, err := addOptions("api/stream/games-by-users", opts)
+	if err != nil {
+		return failedGamesStream(err)
+	}
+
+	return s.streamGames(ctx, u, userIDs)
+}
+
+func (s *GamesService) StreamByIDs(
+	ctx context.Context, streamID string, IDs []string,
+) (<-chan *GamesStreamEvent, <-chan error) {
+	u := fmt.Sprintf("api/stream/games/%v", streamID)
+
+	return s.streamGames(ctx, u, IDs)
+}
+
+func (s *GamesService) AddToStream(ctx context.Context, streamID string, IDs []string) (*Response, error) {
+	u := fmt.Sprintf("api/stream/games/%v/add", streamID)
+	req, err := s.client.NewRequest("POST", u, strings.Join(IDs, ","))
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+func (s *GamesService) streamGames(
+	ctx context.Context, u string, IDs []string,
+) (<-chan *GamesStreamEvent, <-chan error) {
+	evCh := make(chan *GamesStreamEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(evCh)
+			close(errCh)
+		}()
+
+		req, err := s.client.NewRequest("POST", u, strings.Join(IDs, ","))
+
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+		err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+			ev, err := decodeGamesStreamEvent(line)
 			if err != nil {
-				errCh <- err
-				break
+				return err
 			}
 
-			for _, g := range games {
-				gch <- g
+			select {
+			case evCh <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
 			}
+		})
 
-			if len(games) < max {
-				close(gch)
-				close(errCh)
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return evCh, errCh
+}
+
+func failedGamesStream(err error) (<-chan *GamesStreamEvent, <-chan error) {
+	evCh := make(chan *GamesStreamEvent)
+	errCh := make(chan error, 1)
+
+	errCh <- errors.WithStack(err)
+
+	close(evCh)
+	close(errCh)
+
+	return evCh, errCh
+}
+
+func decodeGamesStreamEvent(line json.RawMessage) (*GamesStreamEvent, error) {
+	var probe struct {
+		ID       string `json:"id"`
+		LastMove string `json:"lm"`
+		Status   int    `json:"status"`
+	}
+
+	if err := json.Unmarshal(line, &probe); err != nil {
+		return nil, err
+	}
+
+	ev := &GamesStreamEvent{GameID: probe.ID}
+
+	if probe.LastMove != "" {
+		ev.Type = GameMoved
+		ev.Move = new(StreamedMove)
+
+		return ev, json.Unmarshal(line, ev.Move)
+	}
+
+	// Statuses below 25 (aborted) are created and started.
+	ev.Type = GameFinished
+	if probe.Status < 25 {
+		ev.Type = GameStarted
+	}
+
+	ev.Game = new(StreamedGameStatus)
+
+	return ev, json.Unmarshal(line, ev.Game)
+}
+
+const maxExportIDs = 300
+
+// ExportByIDs exports the games in batches of up to 300 IDs. Once all batches
+// are done, IDs Lichess returned no game for are reported as a
+// *MissingGamesError on the error channel.
+func (s *GamesService) ExportByIDs(ctx context.Context, IDs []string, opts ExportOptions) (<-chan *Game, <-chan error) {
+	gch := make(chan *Game)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(gch)
+			close(errCh)
+		}()
+
+		u, err := addOptions("api/games/export/_ids", opts)
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		found := make(map[string]bool, len(IDs))
 
-				break
+		for start := 0; start < len(IDs); start += maxExportIDs {
+			end := start + maxExportIDs
+			if end > len(IDs) {
+				end = len(IDs)
 			}
 
-			since = int(games[max-1].CreatedAt)
+			req, err := s.client.NewRequest("POST", u, strings.Join(IDs[start:end], ","))
+
+			if err != nil {
+				errCh <- errors.WithStack(err)
+				return
+			}
+
+			req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+			err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+				game := new(Game)
+				if err := json.Unmarshal(line, game); err != nil {
+					return err
+				}
+
+				found[game.ID] = true
+
+				select {
+				case gch <- game:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+
+		var missing []string
+
+		for _, ID := range IDs {
+			if !found[ID] {
+				missing = append(missing, ID)
+			}
+		}
+
+		if len(missing) > 0 {
+			errCh <- &MissingGamesError{IDs: missing}
 		}
 	}()
 
 	return gch, errCh
 }
+
+// ExportByIDsPGN writes the PGN of the games into w, in batches of up to 300
+// IDs. Unlike ExportByIDs, missing games are not reported.
+func (s *GamesService) ExportByIDsPGN(
+	ctx context.Context, IDs []string, opts ExportOptions, w io.Writer,
+) (*Response, error) {
+	u, err := addOptions("api/games/export/_ids", opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var resp *Response
+
+	for start := 0; start < len(IDs); start += maxExportIDs {
+		end := start + maxExportIDs
+		if end > len(IDs) {
+			end = len(IDs)
+		}
+
+		req, err := s.client.NewRequest("POST", u, strings.Join(IDs[start:end], ","))
+
+		if err != nil {
+			return resp, errors.WithStack(err)
+		}
+
+		req.Header.Set("Accept", mediaTypePGN)
+
+		resp, err = s.client.streamTo(ctx, req, w)
+
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *GamesService) ListByIDsPGN(
+	ctx context.Context, IDs []string, opts ExportOptions,
+) ([]*pgn.Game, *Response, error) {
+	var buf bytes.Buffer
+
+	resp, err := s.ExportByIDsPGN(ctx, IDs, opts, &buf)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	games, err := pgn.Parse(buf.String())
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return games, resp, nil
+}
+
+type ImportedGame struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+func (s *GamesService) Import(ctx context.Context, pgn string) (*ImportedGame, *Response, error) {
+	req, err := s.client.NewRequest("POST", "api/import", url.Values{"pgn": {pgn}})
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	game := new(ImportedGame)
+
+	resp, err := s.client.Do(ctx, req, game)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return game, resp, nil
+}
+
+type ImportResult struct {
+	// Index is the position of the game in the imported PGN, starting at 0.
+	Index int
+	Game  *ImportedGame
+	Err   error
+}
+
+// ImportAll imports the games of a multi-game PGN one by one.
+func (s *GamesService) ImportAll(ctx context.Context, pgn string) ([]*ImportResult, error) {
+	games := splitPGN(pgn)
+	results := make([]*ImportResult, 0, len(games))
+
+	for i, g := range games {
+		var game *ImportedGame
+
+		err := retryRateLimited(ctx, func() (err error) {
+			game, _, err = s.Import(ctx, g)
+			return err
+		})
+
+		results = append(results, &ImportResult{Index: i, Game: game, Err: err})
+
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+	}
+
+	return results, nil
+}
+
+func splitPGN(pgn string) []string {
+	var (
+		games     []string
+		current   []string
+		inMoves   bool
+		hasTokens bool
+	)
+
+	flush := func() {
+		if hasTokens {
+			games = append(games, strings.TrimSpace(strings.Join(current, "\n")))
+		}
+
+		current, inMoves, hasTokens = nil, false, false
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(pgn, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "[") && inMoves {
+			flush()
+		}
+
+		if trimmed != "" {
+			hasTokens = true
+
+			if !strings.HasPrefix(trimmed, "[") {
+				inMoves = true
+			}
+		}
+
+		current = append(current, line)
+	}
+
+	flush()
+
+	return games
+}
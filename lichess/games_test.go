@@ -2,11 +2,16 @@ package lichess
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 func TestGamesService_Get(t *testing.T) {
@@ -47,23 +52,11 @@ func TestGamesService_Get(t *testing.T) {
 	want := &Game{
 		ID:      "12345678",
 		Rated:   true,
-		Variant: "standard",
-		Speed:   "blitz",
-		Status:  "draw",
-		Clock: struct {
-			Initial   int `json:"initial"`
-			Increment int `json:"increment"`
-			TotalTime int `json:"totalTime"`
-		}{Initial: 300, Increment: 3, TotalTime: 420},
-		Opening: struct {
-			Eco  string `json:"eco"`
-			Name string `json:"name"`
-			Ply  int64  `json:"ply"`
-		}(struct {
-			Eco  string
-			Name string
-			Ply  int64
-		}{Eco: "D31", Name: "Semi-Slav Defense: Marshall Gambit", Ply: 7}),
+		Variant: VariantStandard,
+		Speed:   SpeedBlitz,
+		Status:  StatusDraw,
+		Clock:   &Clock{Initial: 300, Increment: 3, TotalTime: 420},
+		Opening: &Opening{Eco: "D31", Name: "Semi-Slav Defense: Marshall Gambit", Ply: 7},
 	}
 
 	if diff := cmp.Diff(game, want); diff != "" {
@@ -108,19 +101,873 @@ func TestGamesService_List(t *testing.T) {
 	want := []*Game{{
 		ID:        "id_1",
 		Rated:     true,
-		Variant:   "standard",
+		Variant:   VariantStandard,
 		Perf:      "rapid",
-		CreatedAt: 1620384484273,
-		Status:    "resign",
+		CreatedAt: fromMillis(1620384484273),
+		Status:    StatusResign,
 	}, {ID: "id_2",
 		Rated:     false,
-		Variant:   "standard",
+		Variant:   VariantStandard,
 		Perf:      "rapid",
-		CreatedAt: 1620381701704,
-		Status:    "mate",
+		CreatedAt: fromMillis(1620381701704),
+		Status:    StatusMate,
 	}}
 
 	if diff := cmp.Diff(games, want); diff != "" {
 		t.Errorf("Responses do not match. Diff: %+v", diff)
 	}
 }
+
+func TestGamesService_StreamMoves(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/stream/game/12345678", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		{
+		  "id": "12345678",
+		  "speed": "blitz",
+		  "rated": true,
+		  "fen": "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+		  "turns": 0,
+		  "status": {"id": 20, "name": "started"},
+		  "players": {
+		    "white": {"user": {"name": "White", "id": "white"}, "rating": 1500},
+		    "black": {"user": {"name": "Black", "id": "black"}, "rating": 1600}
+		  }
+		}
+{"fen":"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR","lm":"e2e4","wc":180,"bc":180}
+
+{"fen":"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR","lm":"e7e5","wc":178,"bc":179}
+`)
+	})
+
+	ctx := context.Background()
+	evCh, errCh := client.Games.StreamMoves(ctx, "12345678")
+
+	var events []*GameStreamEvent
+	for ev := range evCh {
+		events = append(events, ev)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Games.StreamMoves returned error: %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("Games.StreamMoves returned %d events, want 3", len(events))
+	}
+
+	if events[0].Game == nil || events[0].Game.ID != "12345678" || events[0].Game.Players.Black.Rating != 1600 {
+		t.Errorf("Games.StreamMoves returned unexpected game %+v", events[0].Game)
+	}
+
+	want := &StreamedMove{
+		Fen:        "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR",
+		LastMove:   "e7e5",
+		WhiteClock: 178,
+		BlackClock: 179,
+	}
+
+	if diff := cmp.Diff(events[2].Move, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestGamesService_StreamByUsers(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/stream/games-by-users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), "alice,bob"; got != want {
+			t.Errorf("Request body: %v, want %v", got, want)
+		}
+
+		if got, want := r.URL.Query().Get("withCurrentGames"), "true"; got != want {
+			t.Errorf("withCurrentGames: %v, want %v", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `
+		{
+		  "id": "game1",
+		  "rated": true,
+		  "status": 20,
+		  "statusName": "started",
+		  "players": {
+		    "white": {"user": {"name": "Alice", "id": "alice"}, "rating": 1500},
+		    "black": {"user": {"name": "Bob", "id": "bob"}, "rating": 1600}
+		  }
+		}
+{"id":"game1","fen":"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR","lm":"e2e4","wc":180,"bc":180}
+{"id":"game1","rated":true,"status":30,"statusName":"mate","winner":"white"}
+`)
+	})
+
+	ctx := context.Background()
+	evCh, errCh := client.Games.StreamByUsers(ctx, []string{"alice", "bob"}, true)
+
+	var types []GamesStreamEventType
+	for ev := range evCh {
+		if ev.GameID != "game1" {
+			t.Errorf("Games.StreamByUsers returned event for %v, want game1", ev.GameID)
+		}
+
+		types = append(types, ev.Type)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Games.StreamByUsers returned error: %v", err)
+	}
+
+	want := []GamesStreamEventType{GameStarted, GameMoved, GameFinished}
+	if diff := cmp.Diff(types, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestGamesService_StreamByIDs(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/stream/games/club", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.Header.Get("Content-Type"), mediaTypePlainText; got != want {
+			t.Errorf("Content-Type: %v, want %v", got, want)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), "game1,game2"; got != want {
+			t.Errorf("Request body: %v, want %v", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `{"id":"game1","rated":false,"status":20,"statusName":"started"}
+{"id":"game2","fen":"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR","lm":"d2d4","wc":60,"bc":60}
+`)
+	})
+
+	ctx := context.Background()
+	evCh, errCh := client.Games.StreamByIDs(ctx, "club", []string{"game1", "game2"})
+
+	var events []*GamesStreamEvent
+	for ev := range evCh {
+		events = append(events, ev)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Games.StreamByIDs returned error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Games.StreamByIDs returned %d events, want 2", len(events))
+	}
+
+	if events[0].Type != GameStarted || events[0].GameID != "game1" {
+		t.Errorf("Games.StreamByIDs returned first event %+v", events[0])
+	}
+
+	if events[1].Type != GameMoved || events[1].GameID != "game2" || events[1].Move.LastMove != "d2d4" {
+		t.Errorf("Games.StreamByIDs returned second event %+v", events[1])
+	}
+}
+
+func TestGamesService_AddToStream(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/stream/games/club/add", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.Header.Get("Content-Type"), mediaTypePlainText; got != want {
+			t.Errorf("Content-Type: %v, want %v", got, want)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), "game1,game2"; got != want {
+			t.Errorf("Request body: %v, want %v", got, want)
+		}
+	})
+
+	ctx := context.Background()
+	_, err := client.Games.AddToStream(ctx, "club", []string{"game1", "game2"})
+
+	if err != nil {
+		t.Errorf("Games.AddToStream returned error: %v", err)
+	}
+}
+
+func TestGamesService_ExportByIDs(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	var batches []int
+
+	mux.HandleFunc("/api/games/export/_ids", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.URL.Query().Get("clocks"), "true"; got != want {
+			t.Errorf("clocks: %v, want %v", got, want)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		IDs := strings.Split(string(body), ",")
+		batches = append(batches, len(IDs))
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+
+		for _, ID := range IDs {
+			if ID != "missing1" {
+				fmt.Fprintf(w, "{\"id\":%q,\"rated\":true}\n", ID)
+			}
+		}
+	})
+
+	IDs := make([]string, 0, 301)
+	for i := 0; i < 300; i++ {
+		IDs = append(IDs, fmt.Sprintf("game%d", i))
+	}
+
+	IDs = append(IDs, "missing1")
+
+	ctx := context.Background()
+	gch, errCh := client.Games.ExportByIDs(ctx, IDs, ExportOptions{Clocks: Bool(true)})
+
+	count := 0
+	for range gch {
+		count++
+	}
+
+	err := <-errCh
+
+	missingErr, ok := err.(*MissingGamesError)
+	if !ok {
+		t.Fatalf("Games.ExportByIDs returned error %v, want *MissingGamesError", err)
+	}
+
+	if diff := cmp.Diff(missingErr.IDs, []string{"missing1"}); diff != "" {
+		t.Errorf("Missing IDs do not match. Diff: %+v", diff)
+	}
+
+	if count != 300 {
+		t.Errorf("Games.ExportByIDs returned %d games, want 300", count)
+	}
+
+	if diff := cmp.Diff(batches, []int{300, 1}); diff != "" {
+		t.Errorf("Batches do not match. Diff: %+v", diff)
+	}
+}
+
+func TestGamesService_GetCurrent(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/user/test/current-game", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":"12345678","rated":true,"speed":"blitz","status":"started"}`)
+	})
+
+	ctx := context.Background()
+	game, _, err := client.Games.GetCurrent(ctx, "test", ExportOptions{})
+
+	if err != nil {
+		t.Errorf("Games.GetCurrent returned error: %v", err)
+	}
+
+	want := &Game{ID: "12345678", Rated: true, Speed: SpeedBlitz, Status: StatusStarted}
+
+	if diff := cmp.Diff(game, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestGamesService_Playing(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/account/playing", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.Query().Get("nb"), "5"; got != want {
+			t.Errorf("nb: %v, want %v", got, want)
+		}
+
+		fmt.Fprint(w, `{
+			"nowPlaying": [{
+				"gameId": "rCRw1AuO",
+				"fullId": "rCRw1AuOvonq",
+				"color": "black",
+				"fen": "r1bqkbnr/pppp2pp/2n1pp2/8/8/3PP3/PPPB1PPP/RN1QKBNR w KQkq - 2 4",
+				"hasMoved": true,
+				"isMyTurn": false,
+				"lastMove": "b8c6",
+				"opponent": {"id": "philippe", "username": "Philippe", "rating": 1790},
+				"perf": "correspondence",
+				"rated": false,
+				"secondsLeft": 1209600,
+				"source": "friend",
+				"speed": "correspondence",
+				"variant": {"key": "standard", "name": "Standard"}
+			}]
+		}`)
+	})
+
+	ctx := context.Background()
+	games, _, err := client.Games.Playing(ctx, 5)
+
+	if err != nil {
+		t.Errorf("Games.Playing returned error: %v", err)
+	}
+
+	if len(games) != 1 {
+		t.Fatalf("Games.Playing returned %d games, want 1", len(games))
+	}
+
+	g := games[0]
+	if g.FullID != "rCRw1AuOvonq" || g.Opponent.Username != "Philippe" || g.SecondsLeft != 1209600 || g.IsMyTurn {
+		t.Errorf("Games.Playing returned unexpected game %+v", g)
+	}
+
+	if g.Color != Black || g.Speed != SpeedCorrespondence || g.Variant.Key != VariantStandard {
+		t.Errorf("Games.Playing returned %v, %v and %v, want black, correspondence and standard",
+			g.Color, g.Speed, g.Variant.Key)
+	}
+}
+
+func TestGamesService_Import(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/import", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.Header.Get("Content-Type"), mediaTypeForm; got != want {
+			t.Errorf("Content-Type: %v, want %v", got, want)
+		}
+
+		if got, want := r.FormValue("pgn"), "1. e4 e5 *"; got != want {
+			t.Errorf("pgn: %v, want %v", got, want)
+		}
+
+		fmt.Fprint(w, `{"id":"R6iLjwz5","url":"https://lichess.org/R6iLjwz5"}`)
+	})
+
+	ctx := context.Background()
+	game, _, err := client.Games.Import(ctx, "1. e4 e5 *")
+
+	if err != nil {
+		t.Errorf("Games.Import returned error: %v", err)
+	}
+
+	want := &ImportedGame{ID: "R6iLjwz5", URL: "https://lichess.org/R6iLjwz5"}
+
+	if diff := cmp.Diff(game, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestGamesService_ImportAll(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/import", func(w http.ResponseWriter, r *http.Request) {
+		pgn := r.FormValue("pgn")
+
+		if strings.Contains(pgn, "Broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"Invalid PGN"}`)
+
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":"id%d","url":"https://lichess.org/id%d"}`, len(pgn), len(pgn))
+	})
+
+	const pgn = `[Event "First"]
+[Result "1-0"]
+
+1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6 4. Qxf7# 1-0
+
+[Event "Broken"]
+
+1. e5 *
+[Event "Third"]
+
+1. d4 d5 *
+`
+
+	ctx := context.Background()
+	results, err := client.Games.ImportAll(ctx, pgn)
+
+	if err != nil {
+		t.Fatalf("Games.ImportAll returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Games.ImportAll returned %d results, want 3", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Game == nil {
+		t.Errorf("First game should be imported, got %+v", results[0])
+	}
+
+	if results[1].Err == nil || results[1].Index != 1 {
+		t.Errorf("Second game should fail, got %+v", results[1])
+	}
+
+	if results[2].Err != nil || results[2].Game.ID != "id27" {
+		t.Errorf("Third game should be imported, got %+v", results[2])
+	}
+}
+
+func TestGamesService_ImportAll_rateLimited(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	defer func(backoff time.Duration) { rateLimitBackoff = backoff }(rateLimitBackoff)
+	rateLimitBackoff = time.Millisecond
+
+	var requests int
+
+	mux.HandleFunc("/api/import", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// The first game is throttled once, the second one twice.
+		if requests == 1 || requests == 3 || requests == 4 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":"id%d","url":"https://lichess.org/id%d"}`, requests, requests)
+	})
+
+	results, err := client.Games.ImportAll(context.Background(), "[Event \"A\"]\n\n1. e4 *\n\n[Event \"B\"]\n\n1. d4 *\n")
+
+	if err != nil {
+		t.Fatalf("Games.ImportAll returned error: %v", err)
+	}
+
+	if requests != 4 {
+		t.Errorf("Games.ImportAll made %d requests, want 4", requests)
+	}
+
+	if results[0].Err != nil || results[0].Game.ID != "id2" {
+		t.Errorf("First game should be imported on retry, got %+v", results[0])
+	}
+
+	if _, ok := errors.Cause(results[1].Err).(*RateLimitError); !ok {
+		t.Errorf("Second game should fail with *RateLimitError, got %+v", results[1].Err)
+	}
+}
+
+func TestGamesService_All(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/games/user/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+
+		if got, want := r.URL.Query().Get("max"), "50"; got != want {
+			t.Errorf("max: %v, want %v", got, want)
+		}
+
+		if r.URL.Query().Get("until") == "" {
+			for i := 0; i < 50; i++ {
+				fmt.Fprintf(w, "{\"id\":\"page1_%d\",\"createdAt\":%d}\n", i, 2000-i)
+			}
+
+			return
+		}
+
+		if got, want := r.URL.Query().Get("until"), "1950"; got != want {
+			t.Errorf("until: %v, want %v", got, want)
+		}
+
+		fmt.Fprint(w, `{"id":"page2_0","createdAt":1900}`)
+	})
+
+	ctx := context.Background()
+	gch, errCh := client.Games.All(ctx, "test")
+
+	count := 0
+	for range gch {
+		count++
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Games.All returned error: %v", err)
+	}
+
+	if count != 51 {
+		t.Errorf("Games.All returned %d games, want 51", count)
+	}
+}
+
+func TestGamesService_All_cancelled(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	requests := make(chan struct{}, 2)
+
+	mux.HandleFunc("/api/games/user/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+
+		for i := 0; i < 50; i++ {
+			fmt.Fprintf(w, "{\"id\":\"game_%d\",\"createdAt\":%d}\n", i, 2000-i)
+		}
+
+		requests <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, errCh := client.Games.All(ctx, "test")
+
+	// The first page fills the buffer, so sending the second one blocks until
+	// the context is cancelled.
+	<-requests
+	<-requests
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Games.All returned error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Error("Games.All kept sending after the context was cancelled")
+	}
+}
+
+func TestGame_UnmarshalJSON(t *testing.T) {
+	data := `{
+		"id": "q7ZvsdUF",
+		"rated": true,
+		"variant": "chess960",
+		"speed": "blitz",
+		"perf": "chess960",
+		"createdAt": 1514505150384,
+		"lastMoveAt": 1514505592843,
+		"status": "outoftime",
+		"players": {
+			"white": {
+				"user": {"name": "Lance5500", "title": "LM", "patron": true, "id": "lance5500"},
+				"rating": 2389,
+				"ratingDiff": 4,
+				"analysis": {"inaccuracy": 2, "mistake": 1, "blunder": 0, "acpl": 21}
+			},
+			"black": {"aiLevel": 8}
+		},
+		"winner": "white",
+		"initialFen": "bqrkrbnn/pppppppp/8/8/8/8/PPPPPPPP/BQRKRBNN w KQkq - 0 1",
+		"moves": "d4 d5",
+		"clock": {"initial": 300, "increment": 3, "totalTime": 420},
+		"clocks": [30003, 30003, 29435],
+		"analysis": [
+			{"eval": 18},
+			{
+				"mate": -3,
+				"best": "e2e4",
+				"variation": "e4 e5",
+				"judgment": {"name": "Blunder", "comment": "Blunder. e4 was best."}
+			}
+		],
+		"division": {"middle": 18, "end": 42},
+		"tournament": "abcdefgh"
+	}`
+
+	game := new(Game)
+	if err := json.Unmarshal([]byte(data), game); err != nil {
+		t.Fatalf("Game.UnmarshalJSON returned error: %v", err)
+	}
+
+	eval, mate := 18, -3
+	want := &Game{
+		ID:         "q7ZvsdUF",
+		Rated:      true,
+		Variant:    VariantChess960,
+		Speed:      SpeedBlitz,
+		Perf:       "chess960",
+		CreatedAt:  fromMillis(1514505150384),
+		LastMoveAt: fromMillis(1514505592843),
+		Status:     StatusOutOfTime,
+		Players: GamePlayers{
+			White: &GamePlayer{
+				User:       &LightUser{ID: "lance5500", Name: "Lance5500", Title: "LM", Patron: true},
+				Rating:     2389,
+				RatingDiff: 4,
+				Analysis:   &Analysis{Inaccuracy: 2, Mistake: 1, ACPL: 21},
+			},
+			Black: &GamePlayer{AILevel: 8},
+		},
+		Winner:     White,
+		InitialFen: "bqrkrbnn/pppppppp/8/8/8/8/PPPPPPPP/BQRKRBNN w KQkq - 0 1",
+		Moves:      "d4 d5",
+		Clock:      &Clock{Initial: 300, Increment: 3, TotalTime: 420},
+		Clocks:     []int{30003, 30003, 29435},
+		Analysis: []*MoveAnalysis{{Eval: &eval}, {
+			Mate:      &mate,
+			Best:      "e2e4",
+			Variation: "e4 e5",
+			Judgment:  &Judgment{Name: "Blunder", Comment: "Blunder. e4 was best."},
+		}},
+		Division:   &Division{Middle: 18, End: 42},
+		Tournament: "abcdefgh",
+	}
+
+	if diff := cmp.Diff(game, want); diff != "" {
+		t.Errorf("Games do not match. Diff: %+v", diff)
+	}
+}
+
+const testPGN = `[Event "Rated Blitz game"]
+[Site "https://lichess.org/id_1"]
+[Result "1-0"]
+
+1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6 4. Qxf7# 1-0
+
+
+[Event "Rated Blitz game"]
+[Site "https://lichess.org/id_2"]
+[Result "*"]
+
+1. d4 d5 *
+
+
+`
+
+func TestGamesService_GetPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/game/export/id_1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept: %v, want %v", got, want)
+		}
+
+		if got, want := r.URL.Query().Get("evals"), "false"; got != want {
+			t.Errorf("evals: %v, want %v", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypePGN)
+		fmt.Fprint(w, testPGN[:strings.Index(testPGN, "\n\n\n")+3])
+	})
+
+	ctx := context.Background()
+	game, _, err := client.Games.GetPGN(ctx, "id_1", ExportOptions{Evals: Bool(false)})
+
+	if err != nil {
+		t.Fatalf("Games.GetPGN returned error: %v", err)
+	}
+
+	if game.Tag("Event") != "Rated Blitz game" || game.Result != "1-0" || game.Moves[6].SAN != "Qxf7#" {
+		t.Errorf("Games.GetPGN returned %+v", game)
+	}
+
+	var buf strings.Builder
+
+	if _, err := client.Games.ExportGamePGN(ctx, "id_1", ExportOptions{Evals: Bool(false)}, &buf); err != nil {
+		t.Errorf("Games.ExportGamePGN returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), `[Event "Rated Blitz game"]`) {
+		t.Errorf("Games.ExportGamePGN wrote %q", buf.String())
+	}
+}
+
+func TestGamesService_GetCurrentPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/user/test/current-game", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept: %v, want %v", got, want)
+		}
+
+		if got, want := r.URL.Query().Get("clocks"), "true"; got != want {
+			t.Errorf("clocks: %v, want %v", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypePGN)
+		fmt.Fprint(w, testPGN[strings.Index(testPGN, "\n\n\n")+3:])
+	})
+
+	game, _, err := client.Games.GetCurrentPGN(context.Background(), "test", ExportOptions{Clocks: Bool(true)})
+
+	if err != nil {
+		t.Fatalf("Games.GetCurrentPGN returned error: %v", err)
+	}
+
+	if got, want := game.Tag("Site"), "https://lichess.org/id_2"; got != want {
+		t.Errorf("Site tag is %v, want %v", got, want)
+	}
+}
+
+func TestGamesService_ListByIDsPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	var batches []int
+
+	mux.HandleFunc("/api/games/export/_ids", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept: %v, want %v", got, want)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		ids := strings.Split(string(body), ",")
+		batches = append(batches, len(ids))
+
+		w.Header().Set("Content-Type", mediaTypePGN)
+
+		fmt.Fprintf(w, "[Site \"https://lichess.org/%v\"]\n[Result \"*\"]\n\n1. e4 *\n\n\n", ids[0])
+	})
+
+	ids := make([]string, 301)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id%d", i)
+	}
+
+	games, _, err := client.Games.ListByIDsPGN(context.Background(), ids, ExportOptions{})
+
+	if err != nil {
+		t.Fatalf("Games.ListByIDsPGN returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(batches, []int{300, 1}); diff != "" {
+		t.Errorf("Batches do not match. Diff: %+v", diff)
+	}
+
+	if len(games) != 2 || games[1].Tag("Site") != "https://lichess.org/id300" {
+		t.Errorf("Games.ListByIDsPGN returned %d games", len(games))
+	}
+}
+
+func TestGamesService_ExportPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/games/user/test", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept: %v, want %v", got, want)
+		}
+
+		if got, want := r.URL.Query().Get("perfType"), "blitz"; got != want {
+			t.Errorf("perfType: %v, want %v", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypePGN)
+		fmt.Fprint(w, testPGN)
+	})
+
+	ctx := context.Background()
+
+	var buf strings.Builder
+
+	_, err := client.Games.ExportPGN(ctx, "test", ListOptions{PerfType: []PerfType{PerfBlitz}}, &buf)
+
+	if err != nil {
+		t.Errorf("Games.ExportPGN returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(buf.String(), testPGN); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+
+	games, _, err := client.Games.ListPGN(ctx, "test", ListOptions{PerfType: []PerfType{PerfBlitz}})
+
+	if err != nil {
+		t.Errorf("Games.ListPGN returned error: %v", err)
+	}
+
+	if len(games) != 2 {
+		t.Fatalf("Games.ListPGN returned %d games, want 2", len(games))
+	}
+
+	if _, err := (&Game{Pgn: testPGN}).ParsePGN(); err != nil {
+		t.Errorf("Game.ParsePGN returned error: %v", err)
+	}
+
+	if got, want := games[1].Tag("Site"), "https://lichess.org/id_2"; got != want {
+		t.Errorf("Site tag is %v, want %v", got, want)
+	}
+
+	if got, want := games[0].Moves[6].SAN, "Qxf7#"; got != want {
+		t.Errorf("Last move is %v, want %v", got, want)
+	}
+}
+
+func TestGame_Replay(t *testing.T) {
+	game := &Game{
+		Variant:    VariantFromPosition,
+		InitialFen: "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1",
+		Moves:      "Ra8#",
+	}
+
+	replayed, err := game.Replay()
+
+	if err != nil {
+		t.Fatalf("Game.Replay returned error: %v", err)
+	}
+
+	if got, want := replayed.Result(), "1-0"; got != want {
+		t.Errorf("Result is %v, want %v", got, want)
+	}
+
+	zh := &Game{Variant: VariantCrazyhouse, Moves: "e4 d5 exd5 Qxd5 Nc3 Qa5 P@d5"}
+
+	if _, err := zh.Replay(); err != nil {
+		t.Errorf("Game.Replay returned error for a crazyhouse game: %v", err)
+	}
+
+	if _, err := (&Game{Variant: VariantStandard, Moves: "e4 e4"}).Replay(); err == nil {
+		t.Error("Game.Replay should fail on illegal moves")
+	}
+}
+
+func TestGame_Timeline(t *testing.T) {
+	e4, e5, qh5, mate := 20, 25, 0, 1
+	game := &Game{
+		Moves:    "e4 e5 Qh5 Nc6",
+		Clock:    &Clock{Initial: 180, Increment: 2},
+		Clocks:   []int{18003, 18003, 17503, 16003},
+		Analysis: []*MoveAnalysis{{Eval: &e4}, {Eval: &e5}, {Eval: &qh5}, {Mate: &mate}},
+	}
+
+	timeline := game.Timeline()
+
+	want := []*TimelinePly{
+		{Ply: 1, Color: White, SAN: "e4", HasClock: true, Clock: 180030 * time.Millisecond,
+			Analysis: game.Analysis[0]},
+		{Ply: 2, Color: Black, SAN: "e5", HasClock: true, Clock: 180030 * time.Millisecond,
+			Analysis: game.Analysis[1]},
+		{Ply: 3, Color: White, SAN: "Qh5", HasClock: true, Clock: 175030 * time.Millisecond,
+			Spent: 7 * time.Second, Analysis: game.Analysis[2]},
+		{Ply: 4, Color: Black, SAN: "Nc6", HasClock: true, Clock: 160030 * time.Millisecond,
+			Spent: 22 * time.Second, Analysis: game.Analysis[3]},
+	}
+
+	if !cmp.Equal(timeline, want) {
+		t.Errorf("Game.Timeline returned %+v", cmp.Diff(timeline, want))
+	}
+
+	black := (&Game{InitialFen: "4k3/8/8/8/8/8/8/4K3 b - - 0 1", Moves: "Kd7 Kd2"}).Timeline()
+
+	if black[0].Color != Black || black[1].Color != White || black[0].HasClock {
+		t.Errorf("Game.Timeline returned %+v %+v for a game starting with black", black[0], black[1])
+	}
+}
@@ -20,56 +20,171 @@ import (
 
 const (
 	defaultBaseURL        = "https://lichess.org/"
+	defaultExplorerURL    = "https://explorer.lichess.ovh/"
+	defaultTablebaseURL   = "https://tablebase.lichess.ovh/"
+	defaultEngineURL      = "https://engine.lichess.ovh/"
 	userAgent             = "go-lichess-api-client"
 	contentType           = "application/json"
 	mediaTypeEnableNDJson = "application/x-ndjson"
+	mediaTypePlainText    = "text/plain"
+	mediaTypeForm         = "application/x-www-form-urlencoded"
+	mediaTypePGN          = "application/x-chess-pgn"
+	mediaTypeLichessV3    = "application/vnd.lichess.v3+json"
 )
 
 type Client struct {
 	client *http.Client
+	// streamClient is client without its overall timeout, for responses
+	// that stay open for as long as the streamed games last.
+	streamClient *http.Client
 
-	baseURL   *url.URL
-	UserAgent string
-	apiKey    string
+	baseURL      *url.URL
+	explorerURL  *url.URL
+	tablebaseURL *url.URL
+	engineURL    *url.URL
+	UserAgent    string
+	apiKey       string
 
 	rateLimiter *rate.Limiter
 
 	common service
 
 	// Services used for talking to different parts of the lichess API.
-	Users   *UsersService
-	Account *AccountService
-	Games   *GamesService
+	Users      *UsersService
+	Account    *AccountService
+	Games      *GamesService
+	Explorer   *ExplorerService
+	Tablebase  *TablebaseService
+	Analysis   *AnalysisService
+	Studies    *StudiesService
+	Broadcasts *BroadcastsService
+	Messages   *MessagesService
+	TV         *TVService
+	Simuls     *SimulsService
 }
 
 func NewClient(apiKey string, httpClient *http.Client) *Client {
+	c, _ := NewClientWithOptions(apiKey, httpClient)
+
+	return c
+}
+
+// ClientOption configures a Client created by NewClientWithOptions.
+type ClientOption func(*Client) error
+
+// WithBaseURL sets the lichess server, e.g. a local lila instance.
+func WithBaseURL(rawURL string) ClientOption {
+	return func(c *Client) (err error) {
+		c.baseURL, err = parseHostURL(rawURL)
+		return err
+	}
+}
+
+// WithExplorerURL sets the host of the opening explorer.
+func WithExplorerURL(rawURL string) ClientOption {
+	return func(c *Client) (err error) {
+		c.explorerURL, err = parseHostURL(rawURL)
+		return err
+	}
+}
+
+// WithTablebaseURL sets the host of the endgame tablebase.
+func WithTablebaseURL(rawURL string) ClientOption {
+	return func(c *Client) (err error) {
+		c.tablebaseURL, err = parseHostURL(rawURL)
+		return err
+	}
+}
+
+// WithEngineURL sets the host of the external engine endpoints.
+func WithEngineURL(rawURL string) ClientOption {
+	return func(c *Client) (err error) {
+		c.engineURL, err = parseHostURL(rawURL)
+		return err
+	}
+}
+
+// NewClientWithOptions is NewClient with the hosts of the services
+// overridable. It fails if an option is given an invalid URL.
+func NewClientWithOptions(apiKey string, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
 	if httpClient == nil {
 		httpClient = defaultHTTPClient()
 	}
 
 	baseURL, _ := url.Parse(defaultBaseURL)
+	explorerURL, _ := url.Parse(defaultExplorerURL)
+	tablebaseURL, _ := url.Parse(defaultTablebaseURL)
+	engineURL, _ := url.Parse(defaultEngineURL)
 
 	rl := rate.NewLimiter(rate.Every(1*time.Second), 50)
 
-	c := &Client{client: httpClient, baseURL: baseURL, apiKey: apiKey, UserAgent: userAgent}
+	c := &Client{
+		client:       httpClient,
+		baseURL:      baseURL,
+		explorerURL:  explorerURL,
+		tablebaseURL: tablebaseURL,
+		engineURL:    engineURL,
+		apiKey:       apiKey,
+		UserAgent:    userAgent,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	streamClient := *httpClient
+	streamClient.Timeout = 0
+	c.streamClient = &streamClient
+
 	c.common.client = c
 	c.rateLimiter = rl
 	c.Users = (*UsersService)(&c.common)
 	c.Account = (*AccountService)(&c.common)
 	c.Games = (*GamesService)(&c.common)
+	c.Explorer = (*ExplorerService)(&c.common)
+	c.Tablebase = (*TablebaseService)(&c.common)
+	c.Analysis = (*AnalysisService)(&c.common)
+	c.Studies = (*StudiesService)(&c.common)
+	c.Broadcasts = (*BroadcastsService)(&c.common)
+	c.Messages = (*MessagesService)(&c.common)
+	c.TV = (*TVService)(&c.common)
+	c.Simuls = (*SimulsService)(&c.common)
+
+	return c, nil
+}
 
-	return c
+// parseHostURL parses an absolute http(s) URL, adding the trailing slash
+// request paths are resolved against.
+func parseHostURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URL %q", rawURL)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("URL %q must be absolute with an http or https scheme", rawURL)
+	}
+
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return u, nil
 }
 
 func defaultHTTPClient() *http.Client {
 	var transport = &http.Transport{
 		DialContext: (&net.Dialer{
 			Timeout: time.Second * 5,
-		}).DialContext}
+		}).DialContext,
+		ResponseHeaderTimeout: time.Second * 10,
+	}
 
 	return &http.Client{
-		Timeout:   time.Second * 10,
 		Transport: transport,
+		Timeout:   time.Second * 10,
 	}
 }
 
@@ -81,26 +196,67 @@ func (c *Client) SetLimits(limit time.Duration, burst uint) error {
 
 type Response struct {
 	*http.Response
+
+	// LastModified is parsed from the Last-Modified header, when present.
+	LastModified time.Time
 }
 
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
 
+	if lm := r.Header.Get("Last-Modified"); lm != "" {
+		response.LastModified, _ = http.ParseTime(lm)
+	}
+
 	return response
 }
 
+// Form wraps a struct to be sent as an application/x-www-form-urlencoded
+// request body, encoded following the url tags of its fields.
+type Form struct {
+	V interface{}
+}
+
+// NewRequest creates a request for urlStr, resolved against the base URL. A
+// leading slash in urlStr is dropped, so that the path of the base URL is kept.
+// A string body is sent as text/plain, url.Values and Form as a form and any
+// other non-nil body as JSON.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
-	if !strings.HasSuffix(c.baseURL.Path, "/") {
-		return nil, fmt.Errorf("baseURL must have a trailing slash, but %q does not", c.baseURL)
+	return c.newRequest(c.baseURL, method, urlStr, body)
+}
+
+// newRequest is NewRequest for services hosted outside of the base URL.
+func (c *Client) newRequest(baseURL *url.URL, method, urlStr string, body interface{}) (*http.Request, error) {
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		return nil, fmt.Errorf("baseURL must have a trailing slash, but %q does not", baseURL)
 	}
 
-	u, err := c.baseURL.Parse(urlStr)
+	u, err := baseURL.Parse(strings.TrimPrefix(urlStr, "/"))
 	if err != nil {
 		return nil, err
 	}
 
 	var buf io.ReadWriter
-	if body != nil {
+
+	var bodyType string
+
+	switch b := body.(type) {
+	case nil:
+	case string:
+		buf = bytes.NewBufferString(b)
+		bodyType = mediaTypePlainText
+	case url.Values:
+		buf = bytes.NewBufferString(b.Encode())
+		bodyType = mediaTypeForm
+	case Form:
+		values, err := encodeValues(b.V)
+		if err != nil {
+			return nil, err
+		}
+
+		buf = bytes.NewBufferString(values.Encode())
+		bodyType = mediaTypeForm
+	default:
 		buf = &bytes.Buffer{}
 		enc := json.NewEncoder(buf)
 		enc.SetEscapeHTML(true)
@@ -109,6 +265,8 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		if err != nil {
 			return nil, err
 		}
+
+		bodyType = contentType
 	}
 
 	req, err := http.NewRequest(method, u.String(), buf)
@@ -116,8 +274,8 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		return nil, err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", contentType)
+	if bodyType != "" {
+		req.Header.Set("Content-Type", bodyType)
 	}
 
 	req.Header.Set("Accept", contentType)
@@ -161,7 +319,54 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return resp, err
 }
 
+// streamTo copies a streamed response body into w as it arrives.
+func (c *Client) streamTo(ctx context.Context, req *http.Request, w io.Writer) (*Response, error) {
+	resp, err := c.send(ctx, c.streamClient, req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+
+	return resp, err
+}
+
+func (c *Client) stream(ctx context.Context, req *http.Request, fn func(json.RawMessage) error) error {
+	resp, err := c.send(ctx, c.streamClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+
+	for {
+		var line json.RawMessage
+
+		if err := dec.Decode(&line); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+}
+
 func (c *Client) bareDo(ctx context.Context, req *http.Request) (*Response, error) {
+	return c.send(ctx, c.client, req)
+}
+
+func (c *Client) send(ctx context.Context, httpClient *http.Client, req *http.Request) (*Response, error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
@@ -173,7 +378,7 @@ func (c *Client) bareDo(ctx context.Context, req *http.Request) (*Response, erro
 
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	resp, err := httpClient.Do(req)
 
 	if err != nil {
 		select {
@@ -202,6 +407,16 @@ func (c *Client) checkResponse(r *http.Response) error {
 
 	if err == nil && data != nil {
 		_ = json.Unmarshal(data, errorResponse)
+
+		// lichess itself reports errors as {"error": "..."}.
+		if errorResponse.Message == "" {
+			var lichessError struct {
+				Error string `json:"error"`
+			}
+
+			_ = json.Unmarshal(data, &lichessError)
+			errorResponse.Message = lichessError.Error
+		}
 	}
 
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(data))
@@ -218,6 +433,31 @@ func (c *Client) checkResponse(r *http.Response) error {
 	}
 }
 
+// rateLimitBackoff is how long lichess asks clients to wait after a 429.
+var rateLimitBackoff = time.Minute
+
+// retryRateLimited calls fn and, when lichess rate limits it, waits the
+// requested minute and calls it once more. It gives up the wait early when ctx
+// is cancelled.
+func retryRateLimited(ctx context.Context, fn func() error) error {
+	err := fn()
+
+	if _, ok := errors.Cause(err).(*RateLimitError); !ok {
+		return err
+	}
+
+	select {
+	case <-time.After(rateLimitBackoff):
+		return fn()
+	case <-ctx.Done():
+		return err
+	}
+}
+
 type service struct {
 	client *Client
 }
+
+func Bool(v bool) *bool { return &v }
+
+func Int(v int) *int { return &v }
@@ -1,10 +1,16 @@
 package lichess
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 const (
@@ -19,9 +25,15 @@ func setUp() (client *Client, mux *http.ServeMux, teardown func()) {
 
 	server := httptest.NewServer(apiHandler)
 
-	client = NewClient("API_KEY", nil)
-	uri, _ := url.Parse(server.URL + "/")
-	client.baseURL = uri
+	client, err := NewClientWithOptions("API_KEY", nil,
+		WithBaseURL(server.URL),
+		WithExplorerURL(server.URL),
+		WithTablebaseURL(server.URL),
+		WithEngineURL(server.URL),
+	)
+	if err != nil {
+		panic(err)
+	}
 
 	return client, mux, server.Close
 }
@@ -60,4 +72,153 @@ func TestNewClient_withDefaultHTTPClient(t *testing.T) {
 	if c.client == c2.client {
 		t.Error("NewClient returned same http.Clients, but they should differ")
 	}
+
+	if c.client.Timeout == 0 || c.streamClient.Timeout != 0 {
+		t.Errorf("NewClient timeouts are %v and %v for streams, want only the first set",
+			c.client.Timeout, c.streamClient.Timeout)
+	}
+}
+
+func TestNewClientWithOptions_basePath(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lila/game/export/12345678", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"12345678"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := NewClientWithOptions("", nil, WithBaseURL(server.URL+"/lila"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	game, _, err := c.Games.Get(context.Background(), "12345678")
+
+	if err != nil {
+		t.Fatalf("Games.Get returned error: %v", err)
+	}
+
+	if game.ID != "12345678" {
+		t.Errorf("Games.Get returned %+v", game)
+	}
+
+	req, err := c.NewRequest("GET", "/api/account", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	if got, want := req.URL.String(), server.URL+"/lila/api/account"; got != want {
+		t.Errorf("NewRequest URL is %v, want %v", got, want)
+	}
+}
+
+func TestClient_streamTimeout(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"id":"slow"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, _ := NewClientWithOptions("", &http.Client{Timeout: 50 * time.Millisecond}, WithBaseURL(server.URL))
+
+	req, _ := c.NewRequest("GET", "slow", nil)
+	if _, err := c.Do(context.Background(), req, new(User)); err == nil {
+		t.Error("Do should time out on a stalled body")
+	}
+
+	req, _ = c.NewRequest("GET", "slow", nil)
+	if err := c.stream(context.Background(), req, func(json.RawMessage) error { return nil }); err != nil {
+		t.Errorf("stream returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	req, _ = c.NewRequest("GET", "slow", nil)
+	if _, err := c.streamTo(context.Background(), req, &buf); err != nil || buf.String() != `{"id":"slow"}` {
+		t.Errorf("streamTo wrote %q, %v", buf.String(), err)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	c, err := NewClientWithOptions("", nil,
+		WithBaseURL("http://localhost:9663"),
+		WithExplorerURL("http://localhost:9002/explorer/"),
+		WithTablebaseURL("https://tablebase.example.com/api"),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	tests := []struct {
+		got  *url.URL
+		want string
+	}{
+		{c.baseURL, "http://localhost:9663/"},
+		{c.explorerURL, "http://localhost:9002/explorer/"},
+		{c.tablebaseURL, "https://tablebase.example.com/api/"},
+		{c.engineURL, defaultEngineURL},
+	}
+
+	for _, tt := range tests {
+		if got := tt.got.String(); got != tt.want {
+			t.Errorf("NewClientWithOptions URL is %v, want %v", got, tt.want)
+		}
+	}
+
+	req, err := c.newRequest(c.explorerURL, "GET", "masters?fen=x", nil)
+	if err != nil {
+		t.Fatalf("newRequest returned error: %v", err)
+	}
+
+	if got, want := req.URL.String(), "http://localhost:9002/explorer/masters?fen=x"; got != want {
+		t.Errorf("newRequest URL is %v, want %v", got, want)
+	}
+
+	for _, rawURL := range []string{"", "localhost:9663", "/api", "ftp://lichess.org/", "http://%zz"} {
+		if _, err := NewClientWithOptions("", nil, WithBaseURL(rawURL)); err == nil {
+			t.Errorf("NewClientWithOptions accepted base URL %q", rawURL)
+		}
+	}
+}
+
+func TestClient_NewRequest_bodies(t *testing.T) {
+	c := NewClient("API_KEY", nil)
+
+	type challenge struct {
+		Rated bool `url:"rated"`
+		Limit int  `url:"clock.limit,omitempty"`
+	}
+
+	tests := []struct {
+		body        interface{}
+		contentType string
+		want        string
+	}{
+		{"alice,bob", mediaTypePlainText, "alice,bob"},
+		{url.Values{"pgn": {"1. e4 *"}}, mediaTypeForm, "pgn=1.+e4+%2A"},
+		{Form{V: challenge{Rated: true, Limit: 300}}, mediaTypeForm, "clock.limit=300&rated=true"},
+		{map[string]string{"a": "b"}, contentType, "{\"a\":\"b\"}\n"},
+	}
+
+	for _, tt := range tests {
+		req, err := c.NewRequest("POST", "api/test", tt.body)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+
+		if got := req.Header.Get("Content-Type"); got != tt.contentType {
+			t.Errorf("NewRequest Content-Type is %v, want %v", got, tt.contentType)
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		if got := string(body); got != tt.want {
+			t.Errorf("NewRequest body is %q, want %q", got, tt.want)
+		}
+	}
 }
@@ -0,0 +1,60 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+type MessagesService service
+
+// messageBlocked is the error lichess answers with when the recipient does not
+// accept messages from the sender.
+const messageBlocked = "The recipient doesn't accept your messages"
+
+// Send sends a private message to a user. A *MessageBlockedError is returned
+// when the user does not accept messages from the sender.
+func (s *MessagesService) Send(ctx context.Context, username, text string) (*Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("inbox/%v", username), url.Values{"text": {text}})
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	if errResp, ok := errors.Cause(err).(*ErrorResponse); ok &&
+		errResp.Response.StatusCode == http.StatusBadRequest && errResp.Message == messageBlocked {
+		return resp, &MessageBlockedError{Username: username, Message: errResp.Message}
+	}
+
+	return resp, err
+}
+
+type MessageResult struct {
+	Username string
+	Err      error
+}
+
+// SendAll sends the same message to each of the users in turn.
+func (s *MessagesService) SendAll(ctx context.Context, usernames []string, text string) ([]*MessageResult, error) {
+	results := make([]*MessageResult, 0, len(usernames))
+
+	for _, username := range usernames {
+		err := retryRateLimited(ctx, func() error {
+			_, err := s.Send(ctx, username, text)
+			return err
+		})
+
+		results = append(results, &MessageResult{Username: username, Err: err})
+
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,104 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestMessagesService_Send(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/inbox/thibault", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.FormValue("text"), "You play board 2 tonight"; got != want {
+			t.Errorf("text is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"ok":true}`)
+	})
+
+	mux.HandleFunc("/inbox/blocker", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"The recipient doesn't accept your messages"}`)
+	})
+
+	mux.HandleFunc("/inbox/unscoped", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"Missing scope"}`)
+	})
+
+	if _, err := client.Messages.Send(context.Background(), "thibault", "You play board 2 tonight"); err != nil {
+		t.Errorf("Messages.Send returned error: %v", err)
+	}
+
+	_, err := client.Messages.Send(context.Background(), "blocker", "You play board 2 tonight")
+
+	if blocked, ok := err.(*MessageBlockedError); !ok || blocked.Username != "blocker" {
+		t.Errorf("Messages.Send returned error %v, want *MessageBlockedError", err)
+	}
+
+	_, err = client.Messages.Send(context.Background(), "unscoped", "You play board 2 tonight")
+
+	if errResp, ok := errors.Cause(err).(*ErrorResponse); !ok || errResp.Message != "Missing scope" {
+		t.Errorf("Messages.Send returned error %v, want *ErrorResponse", err)
+	}
+}
+
+func TestMessagesService_SendAll(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	defer func(backoff time.Duration) { rateLimitBackoff = backoff }(rateLimitBackoff)
+	rateLimitBackoff = time.Millisecond
+
+	limited := true
+
+	mux.HandleFunc("/inbox/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/inbox/blocker":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"The recipient doesn't accept your messages"}`)
+		case "/inbox/busy":
+			if limited {
+				limited = false
+
+				w.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+
+			fallthrough
+		default:
+			fmt.Fprint(w, `{"ok":true}`)
+		}
+	})
+
+	results, err := client.Messages.SendAll(context.Background(), []string{"alice", "blocker", "busy"}, "Pairings are out")
+
+	if err != nil {
+		t.Fatalf("Messages.SendAll returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Messages.SendAll returned %d results, want 3", len(results))
+	}
+
+	if results[0].Username != "alice" || results[0].Err != nil {
+		t.Errorf("Messages.SendAll returned %+v for alice", results[0])
+	}
+
+	if _, ok := errors.Cause(results[1].Err).(*MessageBlockedError); !ok {
+		t.Errorf("Messages.SendAll returned error %v for blocker, want *MessageBlockedError", results[1].Err)
+	}
+
+	if results[2].Err != nil {
+		t.Errorf("Messages.SendAll should retry rate limited messages, got %v", results[2].Err)
+	}
+}
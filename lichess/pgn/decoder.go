@@ -0,0 +1,414 @@
+package pgn
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTag
+	tokenComment
+	tokenOpen
+	tokenClose
+	tokenNAG
+	tokenMove
+	tokenResult
+)
+
+type token struct {
+	kind  tokenKind
+	name  string
+	value string
+}
+
+// Decoder reads games one at a time from a multi-game PGN stream.
+type Decoder struct {
+	r       *bufio.Reader
+	pending []token
+	line    int
+	bol     bool
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), line: 1, bol: true}
+}
+
+// Parse decodes every game of s.
+func Parse(s string) ([]*Game, error) {
+	var games []*Game
+
+	dec := NewDecoder(strings.NewReader(s))
+
+	for {
+		g, err := dec.Decode()
+		if err == io.EOF {
+			return games, nil
+		}
+
+		if err != nil {
+			return games, err
+		}
+
+		games = append(games, g)
+	}
+}
+
+// Decode returns the next game of the stream, or io.EOF once there are no
+// more games.
+func (d *Decoder) Decode() (*Game, error) {
+	g := new(Game)
+	empty := true
+
+	for {
+		tok, err := d.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok.kind != tokenTag {
+			break
+		}
+
+		d.pending = d.pending[1:]
+		g.Tags = append(g.Tags, Tag{Name: tok.name, Value: tok.value})
+		empty = false
+	}
+
+	moves, comments, err := d.parseLine(g, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if empty && len(moves) == 0 && len(comments) == 0 && g.Result == "" {
+		return nil, io.EOF
+	}
+
+	g.Moves, g.Comments = moves, comments
+
+	return g, nil
+}
+
+func (d *Decoder) parseLine(g *Game, depth int) ([]*Move, []Comment, error) {
+	var (
+		moves    []*Move
+		leading  []Comment
+		previous *Move
+	)
+
+	for {
+		tok, err := d.next()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch tok.kind {
+		case tokenEOF:
+			if depth > 0 {
+				return nil, nil, d.errorf("unexpected end of variation")
+			}
+
+			return moves, leading, nil
+		case tokenTag:
+			if depth > 0 {
+				return nil, nil, d.errorf("unexpected tag %v in variation", tok.name)
+			}
+
+			// A new game started without a result for this one.
+			d.pending = append([]token{tok}, d.pending...)
+
+			return moves, leading, nil
+		case tokenResult:
+			if depth > 0 {
+				continue
+			}
+
+			g.Result = tok.value
+
+			return moves, leading, nil
+		case tokenMove:
+			previous = &Move{SAN: tok.value}
+			moves = append(moves, previous)
+
+			if len(moves) == 1 && depth > 0 {
+				previous.PreComments, leading = leading, nil
+			}
+		case tokenNAG:
+			n, _ := strconv.Atoi(tok.value)
+
+			if previous != nil {
+				previous.NAGs = append(previous.NAGs, n)
+			}
+		case tokenComment:
+			c := parseComment(tok.value)
+
+			if previous == nil {
+				leading = append(leading, c)
+			} else {
+				previous.Comments = append(previous.Comments, c)
+			}
+		case tokenOpen:
+			if previous == nil {
+				return nil, nil, d.errorf("variation before any move")
+			}
+
+			variation, _, err := d.parseLine(g, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			previous.Variations = append(previous.Variations, variation)
+		case tokenClose:
+			if depth == 0 {
+				return nil, nil, d.errorf("unexpected ')'")
+			}
+
+			return moves, leading, nil
+		}
+	}
+}
+
+func (d *Decoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("pgn: line %d: %v", d.line, fmt.Sprintf(format, args...))
+}
+
+func (d *Decoder) peek() (token, error) {
+	for len(d.pending) == 0 {
+		if err := d.lex(); err != nil {
+			return token{}, err
+		}
+	}
+
+	return d.pending[0], nil
+}
+
+func (d *Decoder) next() (token, error) {
+	tok, err := d.peek()
+	if err != nil {
+		return tok, err
+	}
+
+	d.pending = d.pending[1:]
+
+	return tok, nil
+}
+
+func (d *Decoder) readRune() (rune, error) {
+	r, _, err := d.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+
+	d.bol = r == '\n'
+	if d.bol {
+		d.line++
+	}
+
+	return r, nil
+}
+
+func (d *Decoder) unreadRune() {
+	_ = d.r.UnreadRune()
+}
+
+// lex appends the tokens of the next lexeme to the pending queue. Move numbers
+// yield no token.
+func (d *Decoder) lex() error {
+	for {
+		bol := d.bol
+
+		r, err := d.readRune()
+		if err == io.EOF {
+			d.pending = append(d.pending, token{kind: tokenEOF})
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == '%' && bol:
+			if _, err := d.readUntil('\n'); err != nil {
+				return err
+			}
+
+			continue
+		case r == '[':
+			return d.lexTag()
+		case r == '{':
+			text, err := d.readUntil('}')
+			if err != nil {
+				return err
+			}
+
+			d.pending = append(d.pending, token{kind: tokenComment, value: text})
+		case r == ';':
+			text, err := d.readUntil('\n')
+			if err != nil {
+				return err
+			}
+
+			d.pending = append(d.pending, token{kind: tokenComment, value: text})
+		case r == '(':
+			d.pending = append(d.pending, token{kind: tokenOpen})
+		case r == ')':
+			d.pending = append(d.pending, token{kind: tokenClose})
+		case r == '$':
+			d.pending = append(d.pending, token{kind: tokenNAG, value: d.readSymbol()})
+		default:
+			d.unreadRune()
+			d.lexSymbol(d.readSymbol())
+		}
+
+		return nil
+	}
+}
+
+func (d *Decoder) readUntil(delim rune) (string, error) {
+	var sb strings.Builder
+
+	for {
+		r, err := d.readRune()
+		if err == io.EOF {
+			if delim == '\n' {
+				return sb.String(), nil
+			}
+
+			return "", d.errorf("missing %q", delim)
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if r == delim {
+			return sb.String(), nil
+		}
+
+		sb.WriteRune(r)
+	}
+}
+
+func (d *Decoder) readSymbol() string {
+	var sb strings.Builder
+
+	for {
+		r, err := d.readRune()
+		if err != nil {
+			return sb.String()
+		}
+
+		if unicode.IsSpace(r) || strings.ContainsRune("{}()[];$", r) {
+			d.unreadRune()
+
+			if r == '\n' {
+				d.line--
+			}
+
+			return sb.String()
+		}
+
+		sb.WriteRune(r)
+	}
+}
+
+func (d *Decoder) lexSymbol(sym string) {
+	switch sym {
+	case "1-0", "0-1", "1/2-1/2", "*":
+		d.pending = append(d.pending, token{kind: tokenResult, value: sym})
+		return
+	}
+
+	// Move numbers, possibly glued to the move as in "1.e4".
+	if i := strings.IndexFunc(sym, func(r rune) bool { return r < '0' || r > '9' }); i > 0 && sym[i] == '.' {
+		sym = strings.TrimLeft(sym[i:], ".")
+	} else if i < 0 {
+		return
+	}
+
+	sym = strings.TrimLeft(sym, ".")
+	if sym == "" {
+		return
+	}
+
+	san := strings.TrimRight(sym, "!?")
+	if san == "" {
+		san = sym
+	}
+
+	d.pending = append(d.pending, token{kind: tokenMove, value: san})
+
+	if suffix := sym[len(san):]; suffix != "" {
+		if n, ok := glyphs[suffix]; ok {
+			d.pending = append(d.pending, token{kind: tokenNAG, value: strconv.Itoa(n)})
+		}
+	}
+}
+
+func (d *Decoder) lexTag() error {
+	body, err := d.readTagBody()
+	if err != nil {
+		return err
+	}
+
+	body = strings.TrimSpace(body)
+
+	i := strings.IndexFunc(body, unicode.IsSpace)
+	if i < 0 {
+		return d.errorf("malformed tag [%v]", body)
+	}
+
+	name, value := body[:i], strings.TrimSpace(body[i:])
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return d.errorf("malformed tag value for %v", name)
+	}
+
+	value = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	d.pending = append(d.pending, token{kind: tokenTag, name: name, value: value})
+
+	return nil
+}
+
+// readTagBody reads up to the closing bracket, skipping brackets that are
+// part of the quoted value.
+func (d *Decoder) readTagBody() (string, error) {
+	var (
+		sb      strings.Builder
+		quoted  bool
+		escaped bool
+	)
+
+	for {
+		r, err := d.readRune()
+		if err == io.EOF {
+			return "", d.errorf("missing ']'")
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ']' && !quoted:
+			return sb.String(), nil
+		}
+
+		sb.WriteRune(r)
+	}
+}
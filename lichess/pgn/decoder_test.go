@@ -0,0 +1,137 @@
+package pgn
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const lichessPGN = `[Event "Rated Blitz game"]
+[Site "https://lichess.org/q7ZvsdUF"]
+[Date "2017.12.28"]
+[White "Lance5500"]
+[Black "TryingHard87"]
+[Result "1-0"]
+[WhiteElo "2389"]
+[BlackElo "2498"]
+[TimeControl "300+3"]
+[ECO "D31"]
+[Opening "Semi-Slav Defense: Marshall Gambit"]
+[Annotator "lichess.org"]
+
+` + "1. d4 { [%eval 0.08] [%clk 0:05:00] } 1... d5 { [%eval 0.21] [%clk 0:05:00] } " +
+	"2. c4 { [%eval 0.19] [%clk 0:04:58] } 2... c6?! { (0.19 → 0.73) Inaccuracy. e6 was best. } " +
+	"{ [%eval 0.73] [%clk 0:04:57.3] } (2... e6 3. Nc3 (3. Nf3 Nf6) 3... Nf6) " +
+	"3. Nc3 { [%eval #4] [%clk 0:04:55] } 3... e6 4. e4 dxe4 5. Nxe4 Bb4+ 6. Bd2 Qxd4 7. Bxb4 $10 1-0\n"
+
+func TestDecoder_Decode(t *testing.T) {
+	game, err := NewDecoder(strings.NewReader(lichessPGN)).Decode()
+
+	if err != nil {
+		t.Fatalf("Decoder returned an error: %v", err)
+	}
+
+	if got, want := game.Tag("Opening"), "Semi-Slav Defense: Marshall Gambit"; got != want {
+		t.Errorf("Opening tag is %q, want %q", got, want)
+	}
+
+	if got, want := len(game.Moves), 13; got != want {
+		t.Fatalf("Decoder returned %d moves, want %d", got, want)
+	}
+
+	if game.Result != "1-0" {
+		t.Errorf("Result is %q, want 1-0", game.Result)
+	}
+
+	c6 := game.Moves[3]
+
+	want := &Move{
+		SAN:  "c6",
+		NAGs: []int{6},
+		Comments: []Comment{
+			{Text: "(0.19 → 0.73) Inaccuracy. e6 was best."},
+			{Commands: []Command{{Name: "eval", Value: "0.73"}, {Name: "clk", Value: "0:04:57.3"}}},
+		},
+		Variations: [][]*Move{{
+			{SAN: "e6"},
+			{SAN: "Nc3", Variations: [][]*Move{{{SAN: "Nf3"}, {SAN: "Nf6"}}}},
+			{SAN: "Nf6"},
+		}},
+	}
+
+	if diff := cmp.Diff(c6, want); diff != "" {
+		t.Errorf("Moves do not match. Diff: %+v", diff)
+	}
+
+	if clk, ok := c6.Clock(); !ok || clk != 4*time.Minute+57300*time.Millisecond {
+		t.Errorf("Clock is %v, want 4m57.3s", clk)
+	}
+
+	if eval, ok := c6.Eval(); !ok || eval.Pawns != 0.73 {
+		t.Errorf("Eval is %+v, want 0.73", eval)
+	}
+
+	if eval, ok := game.Moves[4].Eval(); !ok || eval.Mate != 4 {
+		t.Errorf("Eval is %+v, want mate in 4", eval)
+	}
+
+	if got := game.Moves[12].NAGs; len(got) != 1 || got[0] != 10 {
+		t.Errorf("NAGs are %v, want [10]", got)
+	}
+}
+
+func TestDecoder_DecodeMultipleGames(t *testing.T) {
+	const stream = `[Event "First"]
+
+1. e4 e5 2. Nf3 *
+
+
+[Event "Second"]
+[FEN "4k3/8/8/8/8/8/8/4K2R w K - 0 1"]
+
+1.O-O Kd7 ; castled
+2. Rd1+ 1/2-1/2
+`
+
+	dec := NewDecoder(strings.NewReader(stream))
+
+	var games []*Game
+
+	for {
+		g, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("Decoder returned an error: %v", err)
+		}
+
+		games = append(games, g)
+	}
+
+	if len(games) != 2 {
+		t.Fatalf("Decoder returned %d games, want 2", len(games))
+	}
+
+	if got, want := games[1].Moves[1].Comments[0].Text, "castled"; got != want {
+		t.Errorf("Comment is %q, want %q", got, want)
+	}
+
+	if got, want := games[1].Result, "1/2-1/2"; got != want {
+		t.Errorf("Result is %q, want %q", got, want)
+	}
+}
+
+func TestDecoder_ExpectError(t *testing.T) {
+	if _, err := Parse("1. e4 (e5"); err == nil {
+		t.Error("Parse should fail on an unterminated variation")
+	}
+
+	if _, err := Parse(`[Event "Broken`); err == nil {
+		t.Error("Parse should fail on an unterminated tag")
+	}
+}
@@ -0,0 +1,149 @@
+package pgn
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Encoder struct {
+	w       io.Writer
+	written bool
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes g, separating it from the previously encoded game with a blank
+// line.
+func (e *Encoder) Encode(g *Game) error {
+	s := g.String()
+	if e.written {
+		s = "\n\n" + s
+	}
+
+	e.written = true
+	_, err := io.WriteString(e.w, s)
+
+	return err
+}
+
+// String formats the game the way Lichess exports it: one tag per line, a
+// blank line and the movetext on a single line.
+func (g *Game) String() string {
+	var sb strings.Builder
+
+	for _, t := range g.Tags {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(t.Value)
+		fmt.Fprintf(&sb, "[%v \"%v\"]\n", t.Name, value)
+	}
+
+	if len(g.Tags) > 0 {
+		sb.WriteString("\n")
+	}
+
+	var tokens []string
+
+	for _, c := range g.Comments {
+		tokens = append(tokens, c.String())
+	}
+
+	writeLine(&tokens, g.Moves, g.startPly(), true)
+
+	result := g.Result
+	if result == "" {
+		result = g.Tag("Result")
+	}
+
+	if result == "" {
+		result = "*"
+	}
+
+	tokens = append(tokens, result)
+	sb.WriteString(strings.Join(tokens, " "))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func (c Comment) String() string {
+	parts := make([]string, 0, len(c.Commands)+1)
+
+	for _, cmd := range c.Commands {
+		parts = append(parts, fmt.Sprintf("[%%%v %v]", cmd.Name, cmd.Value))
+	}
+
+	if c.Text != "" {
+		parts = append(parts, c.Text)
+	}
+
+	return "{ " + strings.Join(parts, " ") + " }"
+}
+
+// startPly derives the ply of the first move from the FEN tag, if any.
+func (g *Game) startPly() int {
+	fields := strings.Fields(g.Tag("FEN"))
+	if len(fields) < 6 {
+		return 0
+	}
+
+	fullMove, err := strconv.Atoi(fields[5])
+	if err != nil || fullMove < 1 {
+		return 0
+	}
+
+	ply := (fullMove - 1) * 2
+	if fields[1] == "b" {
+		ply++
+	}
+
+	return ply
+}
+
+func writeLine(tokens *[]string, moves []*Move, ply int, forceNumber bool) {
+	for i, m := range moves {
+		for _, c := range m.PreComments {
+			*tokens = append(*tokens, c.String())
+			forceNumber = true
+		}
+
+		san := m.SAN
+
+		var nags []string
+
+		for _, n := range m.NAGs {
+			if g := glyph(n); g != "" && !strings.ContainsAny(san[len(m.SAN):], "!?") {
+				san += g
+			} else {
+				nags = append(nags, "$"+strconv.Itoa(n))
+			}
+		}
+
+		switch {
+		case (ply+i)%2 == 0:
+			*tokens = append(*tokens, fmt.Sprintf("%d. %v", (ply+i)/2+1, san))
+		case forceNumber:
+			*tokens = append(*tokens, fmt.Sprintf("%d... %v", (ply+i)/2+1, san))
+		default:
+			*tokens = append(*tokens, san)
+		}
+
+		*tokens = append(*tokens, nags...)
+		forceNumber = false
+
+		for _, c := range m.Comments {
+			*tokens = append(*tokens, c.String())
+			forceNumber = true
+		}
+
+		for _, v := range m.Variations {
+			var variation []string
+
+			writeLine(&variation, v, ply+i, true)
+			*tokens = append(*tokens, "("+strings.Join(variation, " ")+")")
+			forceNumber = true
+		}
+	}
+}
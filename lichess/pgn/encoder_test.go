@@ -0,0 +1,53 @@
+package pgn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGame_StringRoundTrip(t *testing.T) {
+	games, err := Parse(lichessPGN)
+
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	if diff := cmp.Diff(games[0].String(), lichessPGN); diff != "" {
+		t.Errorf("PGN does not round-trip. Diff: %+v", diff)
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	g := &Game{
+		Tags:   []Tag{{Name: "Event", Value: `Club "Open"`}, {Name: "FEN", Value: "4k3/8/8/8/8/8/8/4K2R b K - 0 7"}},
+		Moves:  []*Move{{SAN: "Kd7", NAGs: []int{1}}, {SAN: "Rh7+", NAGs: []int{3, 14}}},
+		Result: "*",
+	}
+
+	var sb strings.Builder
+
+	enc := NewEncoder(&sb)
+
+	if err := enc.Encode(g); err != nil {
+		t.Fatalf("Encoder returned an error: %v", err)
+	}
+
+	if err := enc.Encode(&Game{}); err != nil {
+		t.Fatalf("Encoder returned an error: %v", err)
+	}
+
+	want := `[Event "Club \"Open\""]
+[FEN "4k3/8/8/8/8/8/8/4K2R b K - 0 7"]
+
+7... Kd7! 8. Rh7+!! $14 *
+
+
+*
+`
+
+	if diff := cmp.Diff(sb.String(), want); diff != "" {
+		t.Errorf("PGN does not match. Diff: %+v", diff)
+	}
+}
@@ -0,0 +1,170 @@
+package pgn
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Game struct {
+	Tags []Tag
+	// Comments precede the first move of the game.
+	Comments []Comment
+	Moves    []*Move
+	Result   string
+}
+
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Tag returns the value of the named tag or an empty string.
+func (g *Game) Tag(name string) string {
+	for _, t := range g.Tags {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+
+	return ""
+}
+
+func (g *Game) SetTag(name, value string) {
+	for i, t := range g.Tags {
+		if t.Name == name {
+			g.Tags[i].Value = value
+			return
+		}
+	}
+
+	g.Tags = append(g.Tags, Tag{Name: name, Value: value})
+}
+
+type Move struct {
+	SAN  string
+	NAGs []int
+	// PreComments precede the first move of a variation.
+	PreComments []Comment
+	Comments    []Comment
+	// Variations are alternatives to this move.
+	Variations [][]*Move
+}
+
+// Comment is a {...} comment with its [%name value] commands split off the
+// text, in the order they appeared.
+type Comment struct {
+	Text     string
+	Commands []Command
+}
+
+type Command struct {
+	Name  string
+	Value string
+}
+
+var commandRe = regexp.MustCompile(`\[%(\w+)\s+([^\]]*)\]`)
+
+func parseComment(s string) Comment {
+	var c Comment
+
+	for _, m := range commandRe.FindAllStringSubmatch(s, -1) {
+		c.Commands = append(c.Commands, Command{Name: m[1], Value: strings.TrimSpace(m[2])})
+	}
+
+	c.Text = strings.Join(strings.Fields(commandRe.ReplaceAllString(s, "")), " ")
+
+	return c
+}
+
+// Command returns the value of the first command with the given name among
+// the comments of the move.
+func (m *Move) Command(name string) (string, bool) {
+	for _, c := range m.Comments {
+		for _, cmd := range c.Commands {
+			if cmd.Name == name {
+				return cmd.Value, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Clock returns the remaining time from a [%clk h:mm:ss] command.
+func (m *Move) Clock() (time.Duration, bool) {
+	v, ok := m.Command("clk")
+	if !ok {
+		return 0, false
+	}
+
+	parts := strings.Split(v, ":")
+	if len(parts) != 3 {
+		return 0, false
+	}
+
+	h, errH := strconv.Atoi(parts[0])
+	min, errM := strconv.Atoi(parts[1])
+	sec, errS := strconv.ParseFloat(parts[2], 64)
+
+	if errH != nil || errM != nil || errS != nil {
+		return 0, false
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(sec*float64(time.Second)), true
+}
+
+// Eval is an engine evaluation from the point of view of white: either Pawns
+// or, when Mate is not zero, the number of moves to mate.
+type Eval struct {
+	Pawns float64
+	Mate  int
+	Depth int
+}
+
+// Eval returns the evaluation from a [%eval 0.17] or [%eval #-3] command.
+func (m *Move) Eval() (*Eval, bool) {
+	v, ok := m.Command("eval")
+	if !ok {
+		return nil, false
+	}
+
+	e := new(Eval)
+
+	if i := strings.IndexByte(v, ','); i >= 0 {
+		depth, err := strconv.Atoi(v[i+1:])
+		if err != nil {
+			return nil, false
+		}
+
+		e.Depth, v = depth, v[:i]
+	}
+
+	var err error
+
+	if strings.HasPrefix(v, "#") {
+		e.Mate, err = strconv.Atoi(v[1:])
+	} else {
+		e.Pawns, err = strconv.ParseFloat(v, 64)
+	}
+
+	if err != nil {
+		return nil, false
+	}
+
+	return e, true
+}
+
+var glyphs = map[string]int{"!": 1, "?": 2, "!!": 3, "??": 4, "!?": 5, "?!": 6}
+
+func glyph(nag int) string {
+	for g, n := range glyphs {
+		if n == nag {
+			return g
+		}
+	}
+
+	return ""
+}
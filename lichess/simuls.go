@@ -0,0 +1,69 @@
+package lichess
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type SimulsService service
+
+type Simul struct {
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	FullName     string          `json:"fullName"`
+	Host         *SimulHost      `json:"host"`
+	Variants     []*SimulVariant `json:"variants"`
+	Text         string          `json:"text,omitempty"`
+	IsCreated    bool            `json:"isCreated"`
+	IsRunning    bool            `json:"isRunning"`
+	IsFinished   bool            `json:"isFinished"`
+	NbApplicants int             `json:"nbApplicants"`
+	NbPairings   int             `json:"nbPairings"`
+	// EstimatedStartAt, StartedAt and FinishedAt are zero until known.
+	EstimatedStartAt Timestamp `json:"estimatedStartAt"`
+	StartedAt        Timestamp `json:"startedAt"`
+	FinishedAt       Timestamp `json:"finishedAt"`
+}
+
+type SimulHost struct {
+	LightUser
+	Rating      int  `json:"rating"`
+	Provisional bool `json:"provisional,omitempty"`
+	Online      bool `json:"online,omitempty"`
+	// GameID is the game the host is currently watched playing.
+	GameID string `json:"gameId,omitempty"`
+}
+
+type SimulVariant struct {
+	Key  Variant `json:"key"`
+	Name string  `json:"name"`
+	Icon string  `json:"icon,omitempty"`
+}
+
+// Simuls are the recently created simuls, by status.
+type Simuls struct {
+	Pending  []*Simul `json:"pending"`
+	Created  []*Simul `json:"created"`
+	Started  []*Simul `json:"started"`
+	Finished []*Simul `json:"finished"`
+}
+
+// List returns the recently created, started and finished simuls. Pending
+// simuls are those of the current user that are not started yet.
+func (s *SimulsService) List(ctx context.Context) (*Simuls, *Response, error) {
+	req, err := s.client.NewRequest("GET", "api/simul", nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	simuls := new(Simuls)
+	resp, err := s.client.Do(ctx, req, simuls)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return simuls, resp, nil
+}
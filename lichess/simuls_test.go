@@ -0,0 +1,113 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimulsService_List(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/simul", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		{
+		  "pending": [],
+		  "created": [
+		    {
+		      "id": "aBcDeFgH",
+		      "host": {
+		        "id": "thibault",
+		        "name": "thibault",
+		        "rating": 2100,
+		        "online": true,
+		        "patron": true
+		      },
+		      "name": "Club night",
+		      "fullName": "Club night simul",
+		      "variants": [
+		        {"key": "standard", "icon": "+", "name": "Standard"},
+		        {"key": "chess960", "icon": "'", "name": "Chess960"}
+		      ],
+		      "isCreated": true,
+		      "isFinished": false,
+		      "isRunning": false,
+		      "estimatedStartAt": 1620000000000,
+		      "nbApplicants": 4,
+		      "nbPairings": 0
+		    }
+		  ],
+		  "started": [],
+		  "finished": [
+		    {
+		      "id": "iJkLmNoP",
+		      "host": {"id": "gm", "name": "GM", "title": "GM", "rating": 2600},
+		      "name": "GM",
+		      "fullName": "GM simul",
+		      "variants": [{"key": "standard", "name": "Standard"}],
+		      "isCreated": false,
+		      "isFinished": true,
+		      "isRunning": false,
+		      "startedAt": 1610000000000,
+		      "finishedAt": 1610003600000,
+		      "nbApplicants": 0,
+		      "nbPairings": 20
+		    }
+		  ]
+		}
+		`)
+	})
+
+	simuls, _, err := client.Simuls.List(context.Background())
+
+	if err != nil {
+		t.Fatalf("Simuls.List returned error: %v", err)
+	}
+
+	want := &Simuls{
+		Pending: []*Simul{},
+		Created: []*Simul{{
+			ID:       "aBcDeFgH",
+			Name:     "Club night",
+			FullName: "Club night simul",
+			Host: &SimulHost{
+				LightUser: LightUser{ID: "thibault", Name: "thibault", Patron: true},
+				Rating:    2100,
+				Online:    true,
+			},
+			Variants: []*SimulVariant{
+				{Key: VariantStandard, Name: "Standard", Icon: "+"},
+				{Key: VariantChess960, Name: "Chess960", Icon: "'"},
+			},
+			IsCreated:        true,
+			NbApplicants:     4,
+			EstimatedStartAt: fromMillis(1620000000000),
+		}},
+		Started: []*Simul{},
+		Finished: []*Simul{{
+			ID:         "iJkLmNoP",
+			Name:       "GM",
+			FullName:   "GM simul",
+			Host:       &SimulHost{LightUser: LightUser{ID: "gm", Name: "GM", Title: "GM"}, Rating: 2600},
+			Variants:   []*SimulVariant{{Key: VariantStandard, Name: "Standard"}},
+			IsFinished: true,
+			NbPairings: 20,
+			StartedAt:  fromMillis(1610000000000),
+			FinishedAt: fromMillis(1610003600000),
+		}},
+	}
+
+	if diff := cmp.Diff(simuls, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+
+	if got := simuls.Finished[0].FinishedAt.Sub(simuls.Finished[0].StartedAt.Time); got != time.Hour {
+		t.Errorf("simul lasted %v, want 1h", got)
+	}
+}
@@ -0,0 +1,179 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type StudiesService service
+
+type StudyExportOptions struct {
+	Clocks     *bool `url:"clocks,omitempty"`
+	Comments   *bool `url:"comments,omitempty"`
+	Variations *bool `url:"variations,omitempty"`
+	// Orientation adds an Orientation tag with the board orientation of each
+	// chapter.
+	Orientation *bool `url:"orientation,omitempty"`
+}
+
+// ExportChapter writes the PGN of a single chapter of a study into w.
+func (s *StudiesService) ExportChapter(
+	ctx context.Context, studyID, chapterID string, opts StudyExportOptions, w io.Writer,
+) (*Response, error) {
+	return s.exportPGN(ctx, fmt.Sprintf("api/study/%v/%v.pgn", studyID, chapterID), opts, w)
+}
+
+// Export writes the PGN of all the chapters of a study into w. The returned
+// Response carries the LastModified time of the study.
+func (s *StudiesService) Export(
+	ctx context.Context, studyID string, opts StudyExportOptions, w io.Writer,
+) (*Response, error) {
+	return s.exportPGN(ctx, fmt.Sprintf("api/study/%v.pgn", studyID), opts, w)
+}
+
+// ExportAll writes the PGN of all the studies of a user into w. Private
+// studies are only included for their owner.
+func (s *StudiesService) ExportAll(
+	ctx context.Context, username string, opts StudyExportOptions, w io.Writer,
+) (*Response, error) {
+	return s.exportPGN(ctx, fmt.Sprintf("api/study/by/%v/export.pgn", username), opts, w)
+}
+
+func (s *StudiesService) exportPGN(
+	ctx context.Context, path string, opts StudyExportOptions, w io.Writer,
+) (*Response, error) {
+	u, err := addOptions(path, opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypePGN)
+
+	return s.client.streamTo(ctx, req, w)
+}
+
+// LastModified returns when a study was last changed without downloading it,
+// so that backups only need to export the studies that changed.
+func (s *StudiesService) LastModified(ctx context.Context, studyID string) (time.Time, *Response, error) {
+	req, err := s.client.NewRequest("HEAD", fmt.Sprintf("api/study/%v.pgn", studyID), nil)
+
+	if err != nil {
+		return time.Time{}, nil, errors.WithStack(err)
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+
+	if err != nil {
+		return time.Time{}, resp, err
+	}
+
+	return resp.LastModified, resp, nil
+}
+
+type StudyMeta struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt Timestamp `json:"createdAt"`
+	UpdatedAt Timestamp `json:"updatedAt"`
+}
+
+// List streams the metadata of the studies of a user.
+func (s *StudiesService) List(ctx context.Context, username string) (<-chan *StudyMeta, <-chan error) {
+	metaCh := make(chan *StudyMeta)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(metaCh)
+			close(errCh)
+		}()
+
+		req, err := s.client.NewRequest("GET", fmt.Sprintf("api/study/by/%v", username), nil)
+
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+		err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+			meta := new(StudyMeta)
+			if err := json.Unmarshal(line, meta); err != nil {
+				return err
+			}
+
+			select {
+			case metaCh <- meta:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return metaCh, errCh
+}
+
+type StudyImportOptions struct {
+	// Name is used for the chapters whose PGN has no Event tag.
+	Name        string  `url:"name,omitempty"`
+	Orientation Color   `url:"orientation,omitempty"`
+	Variant     Variant `url:"variant,omitempty"`
+}
+
+type StudyChapter struct {
+	ID      string                `json:"id"`
+	Name    string                `json:"name"`
+	Players []*StudyChapterPlayer `json:"players"`
+	Status  string                `json:"status"`
+}
+
+type StudyChapterPlayer struct {
+	Name   string `json:"name"`
+	Rating int    `json:"rating,omitempty"`
+}
+
+// ImportPGN adds the games of a PGN as new chapters of an existing study and
+// returns the created chapters.
+func (s *StudiesService) ImportPGN(
+	ctx context.Context, studyID, pgn string, opts StudyImportOptions,
+) ([]*StudyChapter, *Response, error) {
+	body := Form{V: struct {
+		PGN string `url:"pgn"`
+		StudyImportOptions
+	}{pgn, opts}}
+
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("api/study/%v/import-pgn", studyID), body)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	var imported struct {
+		Chapters []*StudyChapter `json:"chapters"`
+	}
+
+	resp, err := s.client.Do(ctx, req, &imported)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return imported.Chapters, resp, nil
+}
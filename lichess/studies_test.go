@@ -0,0 +1,179 @@
+package lichess
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testStudyPGN = `[Event "Repertoire: Italian"]
+[Site "https://lichess.org/study/abcdefgh/ijklmnop"]
+[Result "*"]
+
+1. e4 e5 2. Nf3 Nc6 3. Bc4 *`
+
+func TestStudiesService_Export(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	modified := time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	mux.HandleFunc("/api/study/abcdefgh.pgn", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		if got, want := r.URL.RawQuery, "comments=false"; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		if got, want := r.Header.Get("Accept"), mediaTypePGN; got != want {
+			t.Errorf("Accept header is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, testStudyPGN)
+	})
+
+	var buf bytes.Buffer
+
+	resp, err := client.Studies.Export(context.Background(), "abcdefgh", StudyExportOptions{Comments: Bool(false)}, &buf)
+
+	if err != nil {
+		t.Fatalf("Studies.Export returned error: %v", err)
+	}
+
+	if got := buf.String(); got != testStudyPGN {
+		t.Errorf("Studies.Export wrote %q, want %q", got, testStudyPGN)
+	}
+
+	if !resp.LastModified.Equal(modified) {
+		t.Errorf("Studies.Export LastModified is %v, want %v", resp.LastModified, modified)
+	}
+
+	lastModified, _, err := client.Studies.LastModified(context.Background(), "abcdefgh")
+
+	if err != nil {
+		t.Fatalf("Studies.LastModified returned error: %v", err)
+	}
+
+	if !lastModified.Equal(modified) {
+		t.Errorf("Studies.LastModified returned %v, want %v", lastModified, modified)
+	}
+}
+
+func TestStudiesService_ExportChapter(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/study/abcdefgh/ijklmnop.pgn", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testStudyPGN)
+	})
+
+	mux.HandleFunc("/api/study/by/thibault/export.pgn", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testStudyPGN)
+	})
+
+	var chapter, all bytes.Buffer
+
+	ctx := context.Background()
+	if _, err := client.Studies.ExportChapter(ctx, "abcdefgh", "ijklmnop", StudyExportOptions{}, &chapter); err != nil {
+		t.Errorf("Studies.ExportChapter returned error: %v", err)
+	}
+
+	if _, err := client.Studies.ExportAll(ctx, "thibault", StudyExportOptions{}, &all); err != nil {
+		t.Errorf("Studies.ExportAll returned error: %v", err)
+	}
+
+	if chapter.String() != testStudyPGN || all.String() != testStudyPGN {
+		t.Errorf("Studies exports wrote %q and %q", chapter.String(), all.String())
+	}
+}
+
+func TestStudiesService_List(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/study/by/thibault", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `{"id":"abcdefgh","name":"Italian","createdAt":1600000000000,"updatedAt":1615734566000}
+{"id":"qrstuvwx","name":"Endgames","createdAt":1500000000000,"updatedAt":1500000000000}
+`)
+	})
+
+	metaCh, errCh := client.Studies.List(context.Background(), "thibault")
+
+	var studies []*StudyMeta
+	for meta := range metaCh {
+		studies = append(studies, meta)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("Studies.List returned error: %v", err)
+	}
+
+	want := []*StudyMeta{
+		{ID: "abcdefgh", Name: "Italian", CreatedAt: fromMillis(1600000000000), UpdatedAt: fromMillis(1615734566000)},
+		{ID: "qrstuvwx", Name: "Endgames", CreatedAt: fromMillis(1500000000000), UpdatedAt: fromMillis(1500000000000)},
+	}
+
+	if diff := cmp.Diff(studies, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestStudiesService_ImportPGN(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/study/abcdefgh/import-pgn", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if got, want := r.FormValue("pgn"), testStudyPGN; got != want {
+			t.Errorf("pgn is %q, want %q", got, want)
+		}
+
+		if got, want := r.FormValue("orientation"), "black"; got != want {
+			t.Errorf("orientation is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "chapters": [
+		    {
+		      "id": "ijklmnop",
+		      "name": "Repertoire: Italian",
+		      "players": [{"name": "White"}, {"name": "Black", "rating": 2000}],
+		      "status": "*"
+		    }
+		  ]
+		}
+		`)
+	})
+
+	opts := StudyImportOptions{Orientation: Black}
+	chapters, _, err := client.Studies.ImportPGN(context.Background(), "abcdefgh", testStudyPGN, opts)
+
+	if err != nil {
+		t.Fatalf("Studies.ImportPGN returned error: %v", err)
+	}
+
+	want := []*StudyChapter{{
+		ID:      "ijklmnop",
+		Name:    "Repertoire: Italian",
+		Players: []*StudyChapterPlayer{{Name: "White"}, {Name: "Black", Rating: 2000}},
+		Status:  "*",
+	}}
+
+	if diff := cmp.Diff(chapters, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
@@ -0,0 +1,96 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// TablebaseService looks up endgame positions in the tablebase, which is
+// served from its own host rather than lichess.org.
+type TablebaseService service
+
+// TablebaseCategory is the outcome of a position for the side to move.
+type TablebaseCategory string
+
+const (
+	TablebaseWin         TablebaseCategory = "win"
+	TablebaseSyzygyWin   TablebaseCategory = "syzygy-win"
+	TablebaseMaybeWin    TablebaseCategory = "maybe-win"
+	TablebaseCursedWin   TablebaseCategory = "cursed-win"
+	TablebaseDraw        TablebaseCategory = "draw"
+	TablebaseBlessedLoss TablebaseCategory = "blessed-loss"
+	TablebaseMaybeLoss   TablebaseCategory = "maybe-loss"
+	TablebaseSyzygyLoss  TablebaseCategory = "syzygy-loss"
+	TablebaseLoss        TablebaseCategory = "loss"
+	TablebaseUnknown     TablebaseCategory = "unknown"
+)
+
+// TablebasePosition holds the fields shared by a position and the moves
+// from it. DTZ and DTM are nil when unknown.
+type TablebasePosition struct {
+	Category             TablebaseCategory `json:"category"`
+	DTZ                  *int              `json:"dtz"`
+	PreciseDTZ           *int              `json:"precise_dtz"`
+	DTM                  *int              `json:"dtm"`
+	Checkmate            bool              `json:"checkmate"`
+	Stalemate            bool              `json:"stalemate"`
+	VariantWin           bool              `json:"variant_win"`
+	VariantLoss          bool              `json:"variant_loss"`
+	InsufficientMaterial bool              `json:"insufficient_material"`
+}
+
+type TablebaseResult struct {
+	TablebasePosition
+	// Moves are ranked from best to worst for the side to move.
+	Moves []*TablebaseMove `json:"moves"`
+}
+
+// TablebaseMove is a move with the tablebase data of the position it leads
+// to, hence from the point of view of the opponent.
+type TablebaseMove struct {
+	UCI     string `json:"uci"`
+	SAN     string `json:"san"`
+	Zeroing bool   `json:"zeroing"`
+	TablebasePosition
+}
+
+// Lookup looks up a position given as FEN. The tablebase supports the
+// standard, atomic and antichess variants.
+func (s *TablebaseService) Lookup(
+	ctx context.Context, variant Variant, fen string,
+) (*TablebaseResult, *Response, error) {
+	var path string
+
+	switch variant {
+	case "", VariantStandard, VariantChess960, VariantFromPosition:
+		path = "standard"
+	case VariantAtomic, VariantAntichess:
+		path = string(variant)
+	default:
+		return nil, nil, fmt.Errorf("no tablebase for variant %q", variant)
+	}
+
+	u, err := addOptions(path, struct {
+		Fen string `url:"fen"`
+	}{fen})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.newRequest(s.client.tablebaseURL, "GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	result := new(TablebaseResult)
+	resp, err := s.client.Do(ctx, req, result)
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return result, resp, nil
+}
@@ -0,0 +1,108 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTablebaseService_Lookup(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/standard", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.Query().Get("fen"), "4k3/6KP/8/8/8/8/7p/8 w - - 0 1"; got != want {
+			t.Errorf("fen is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "checkmate": false,
+		  "stalemate": false,
+		  "variant_win": false,
+		  "variant_loss": false,
+		  "insufficient_material": false,
+		  "dtz": 1,
+		  "precise_dtz": 1,
+		  "dtm": 17,
+		  "dtw": null,
+		  "dtc": null,
+		  "category": "win",
+		  "moves": [
+		    {
+		      "uci": "h7h8q",
+		      "san": "h8=Q+",
+		      "zeroing": true,
+		      "checkmate": false,
+		      "stalemate": false,
+		      "variant_win": false,
+		      "variant_loss": false,
+		      "insufficient_material": false,
+		      "dtz": -2,
+		      "precise_dtz": -2,
+		      "dtm": -16,
+		      "category": "loss"
+		    },
+		    {
+		      "uci": "g7g6",
+		      "san": "Kg6",
+		      "zeroing": false,
+		      "checkmate": false,
+		      "stalemate": false,
+		      "variant_win": false,
+		      "variant_loss": false,
+		      "insufficient_material": false,
+		      "dtz": null,
+		      "precise_dtz": null,
+		      "dtm": null,
+		      "category": "unknown"
+		    }
+		  ]
+		}
+		`)
+	})
+
+	result, _, err := client.Tablebase.Lookup(context.Background(), VariantStandard, "4k3/6KP/8/8/8/8/7p/8 w - - 0 1")
+
+	if err != nil {
+		t.Fatalf("Tablebase.Lookup returned error: %v", err)
+	}
+
+	want := &TablebaseResult{
+		TablebasePosition: TablebasePosition{Category: TablebaseWin, DTZ: Int(1), PreciseDTZ: Int(1), DTM: Int(17)},
+		Moves: []*TablebaseMove{
+			{UCI: "h7h8q", SAN: "h8=Q+", Zeroing: true, TablebasePosition: TablebasePosition{
+				Category: TablebaseLoss, DTZ: Int(-2), PreciseDTZ: Int(-2), DTM: Int(-16),
+			}},
+			{UCI: "g7g6", SAN: "Kg6", TablebasePosition: TablebasePosition{Category: TablebaseUnknown}},
+		},
+	}
+
+	if diff := cmp.Diff(result, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestTablebaseService_Lookup_variants(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/antichess", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"category":"draw","moves":[]}`)
+	})
+
+	result, _, err := client.Tablebase.Lookup(context.Background(), VariantAntichess, "8/8/8/8/8/8/1p6/1N6 w - - 0 1")
+
+	if err != nil || result.Category != TablebaseDraw {
+		t.Errorf("Tablebase.Lookup returned %+v, %v", result, err)
+	}
+
+	if _, _, err := client.Tablebase.Lookup(context.Background(), VariantHorde, "8/8/8/8/8/8/8/8 w - - 0 1"); err == nil {
+		t.Error("Tablebase.Lookup should fail for horde")
+	}
+}
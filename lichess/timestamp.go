@@ -0,0 +1,44 @@
+package lichess
+
+import (
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time that lichess sends as milliseconds since the epoch. It
+// encodes back to the same form, so that exported data can be stored as JSON
+// and read again. A zero Timestamp stands for a missing time.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+
+	return []byte(strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*t = fromMillis(ms)
+
+	return nil
+}
+
+func fromMillis(ms int64) Timestamp {
+	if ms == 0 {
+		return Timestamp{}
+	}
+
+	return Timestamp{time.Unix(0, ms*int64(time.Millisecond))}
+}
@@ -0,0 +1,61 @@
+package lichess
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTimestamp_JSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Timestamp
+	}{
+		{"1514505150384", fromMillis(1514505150384)},
+		{"0", Timestamp{}},
+		{"null", Timestamp{}},
+	}
+
+	for _, tt := range tests {
+		var got Timestamp
+
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+			t.Fatalf("Unmarshal %v returned error: %v", tt.json, err)
+		}
+
+		if !got.Equal(tt.want.Time) {
+			t.Errorf("Unmarshal %v is %v, want %v", tt.json, got, tt.want)
+		}
+	}
+
+	data, err := json.Marshal(fromMillis(1514505150384))
+	if err != nil || string(data) != "1514505150384" {
+		t.Errorf("Marshal returned %s, %v", data, err)
+	}
+}
+
+func TestGame_JSONRoundTrip(t *testing.T) {
+	game := &Game{
+		ID:         "q7ZvsdUF",
+		Rated:      true,
+		Variant:    VariantStandard,
+		CreatedAt:  fromMillis(1514505150384),
+		LastMoveAt: fromMillis(1514505592843),
+		Status:     StatusDraw,
+	}
+
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	decoded := new(Game)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal of %s returned error: %v", data, err)
+	}
+
+	if diff := cmp.Diff(decoded, game); diff != "" {
+		t.Errorf("Round trip does not match. Diff: %+v", diff)
+	}
+}
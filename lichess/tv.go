@@ -0,0 +1,231 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type TVService service
+
+type TVChannel string
+
+const (
+	ChannelBest          TVChannel = "best"
+	ChannelUltraBullet   TVChannel = "ultraBullet"
+	ChannelBullet        TVChannel = "bullet"
+	ChannelBlitz         TVChannel = "blitz"
+	ChannelRapid         TVChannel = "rapid"
+	ChannelClassical     TVChannel = "classical"
+	ChannelChess960      TVChannel = "chess960"
+	ChannelCrazyhouse    TVChannel = "crazyhouse"
+	ChannelAntichess     TVChannel = "antichess"
+	ChannelAtomic        TVChannel = "atomic"
+	ChannelHorde         TVChannel = "horde"
+	ChannelKingOfTheHill TVChannel = "kingOfTheHill"
+	ChannelRacingKings   TVChannel = "racingKings"
+	ChannelThreeCheck    TVChannel = "threeCheck"
+	ChannelBot           TVChannel = "bot"
+	ChannelComputer      TVChannel = "computer"
+)
+
+// channelNames maps the display names /api/tv/channels is keyed by to the
+// channel keys used in the other TV endpoints.
+var channelNames = map[string]TVChannel{
+	"Top Rated":        ChannelBest,
+	"UltraBullet":      ChannelUltraBullet,
+	"Bullet":           ChannelBullet,
+	"Blitz":            ChannelBlitz,
+	"Rapid":            ChannelRapid,
+	"Classical":        ChannelClassical,
+	"Chess960":         ChannelChess960,
+	"Crazyhouse":       ChannelCrazyhouse,
+	"Antichess":        ChannelAntichess,
+	"Atomic":           ChannelAtomic,
+	"Horde":            ChannelHorde,
+	"King of the Hill": ChannelKingOfTheHill,
+	"Racing Kings":     ChannelRacingKings,
+	"Three-check":      ChannelThreeCheck,
+	"Bot":              ChannelBot,
+	"Computer":         ChannelComputer,
+}
+
+// TVGame is the game currently shown on a channel.
+type TVGame struct {
+	User   *LightUser `json:"user"`
+	Rating int        `json:"rating"`
+	GameID string     `json:"gameId"`
+	Color  Color      `json:"color"`
+}
+
+// Channels returns the current game of every TV channel. Channels this client
+// does not know of are keyed by their display name.
+func (s *TVService) Channels(ctx context.Context) (map[TVChannel]*TVGame, *Response, error) {
+	req, err := s.client.NewRequest("GET", "api/tv/channels", nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	byName := make(map[string]*TVGame)
+	resp, err := s.client.Do(ctx, req, &byName)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	channels := make(map[TVChannel]*TVGame, len(byName))
+
+	for name, game := range byName {
+		channel, ok := channelNames[name]
+		if !ok {
+			channel = TVChannel(name)
+		}
+
+		channels[channel] = game
+	}
+
+	return channels, resp, nil
+}
+
+type TVFeedEventType string
+
+const (
+	TVFeatured TVFeedEventType = "featured"
+	TVFen      TVFeedEventType = "fen"
+)
+
+type TVFeaturedGame struct {
+	ID          string              `json:"id"`
+	Orientation Color               `json:"orientation"`
+	Players     []*TVFeaturedPlayer `json:"players"`
+	Fen         string              `json:"fen"`
+}
+
+type TVFeaturedPlayer struct {
+	Color  Color      `json:"color"`
+	User   *LightUser `json:"user"`
+	Rating int        `json:"rating"`
+	// Seconds is the time left on the clock of the player.
+	Seconds int `json:"seconds"`
+}
+
+// TVFeedEvent holds Featured when the feed switches to a new game, and Move
+// for each move of the featured game.
+type TVFeedEvent struct {
+	Type     TVFeedEventType
+	Featured *TVFeaturedGame
+	Move     *StreamedMove
+}
+
+// Feed streams the games of the main TV channel.
+func (s *TVService) Feed(ctx context.Context) (<-chan *TVFeedEvent, <-chan error) {
+	return s.feed(ctx, "api/tv/feed")
+}
+
+// ChannelFeed streams the games of a single TV channel.
+func (s *TVService) ChannelFeed(ctx context.Context, channel TVChannel) (<-chan *TVFeedEvent, <-chan error) {
+	return s.feed(ctx, fmt.Sprintf("api/tv/%v/feed", channel))
+}
+
+func (s *TVService) feed(ctx context.Context, u string) (<-chan *TVFeedEvent, <-chan error) {
+	evCh := make(chan *TVFeedEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(evCh)
+			close(errCh)
+		}()
+
+		req, err := s.client.NewRequest("GET", u, nil)
+
+		if err != nil {
+			errCh <- errors.WithStack(err)
+			return
+		}
+
+		req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+		err = s.client.stream(ctx, req, func(line json.RawMessage) error {
+			var msg struct {
+				T TVFeedEventType `json:"t"`
+				D json.RawMessage `json:"d"`
+			}
+
+			if err := json.Unmarshal(line, &msg); err != nil {
+				return err
+			}
+
+			ev := &TVFeedEvent{Type: msg.T}
+
+			switch msg.T {
+			case TVFeatured:
+				ev.Featured = new(TVFeaturedGame)
+				if err := json.Unmarshal(msg.D, ev.Featured); err != nil {
+					return err
+				}
+			case TVFen:
+				ev.Move = new(StreamedMove)
+				if err := json.Unmarshal(msg.D, ev.Move); err != nil {
+					return err
+				}
+			default:
+				return nil
+			}
+
+			select {
+			case evCh <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return evCh, errCh
+}
+
+type ChannelGamesOptions struct {
+	// Nb is the number of games, 10 when unset.
+	Nb int `url:"nb,omitempty"`
+	ExportOptions
+}
+
+// ChannelGames returns the best ongoing games of a TV channel.
+func (s *TVService) ChannelGames(
+	ctx context.Context, channel TVChannel, opts ChannelGamesOptions,
+) ([]*Game, *Response, error) {
+	if opts.PgnInJSON == nil {
+		opts.PgnInJSON = Bool(true)
+	}
+
+	u, err := addOptions(fmt.Sprintf("api/tv/%v", channel), opts)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypeEnableNDJson)
+
+	var games []*Game
+
+	resp, err := s.client.Do(ctx, req, &games)
+
+	if err != nil {
+		return nil, resp, errors.WithStack(err)
+	}
+
+	return games, resp, nil
+}
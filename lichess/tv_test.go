@@ -0,0 +1,162 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTVService_Channels(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/tv/channels", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		{
+		  "Bot": {
+		    "user": {"id": "leelachess", "name": "LeelaChess", "title": "BOT"},
+		    "rating": 2660,
+		    "gameId": "aaaaaaaa",
+		    "color": "black"
+		  },
+		  "Blitz": {
+		    "user": {"id": "drnykterstein", "name": "DrNykterstein", "title": "GM"},
+		    "rating": 3200,
+		    "gameId": "bbbbbbbb",
+		    "color": "white"
+		  },
+		  "Top Rated": {
+		    "user": {"id": "drnykterstein", "name": "DrNykterstein", "title": "GM"},
+		    "rating": 3200,
+		    "gameId": "bbbbbbbb",
+		    "color": "white"
+		  },
+		  "King of the Hill": {
+		    "user": {"id": "koth", "name": "KotH"},
+		    "rating": 2100,
+		    "gameId": "cccccccc",
+		    "color": "white"
+		  }
+		}
+		`)
+	})
+
+	channels, _, err := client.TV.Channels(context.Background())
+
+	if err != nil {
+		t.Fatalf("TV.Channels returned error: %v", err)
+	}
+
+	leela := &LightUser{ID: "leelachess", Name: "LeelaChess", Title: "BOT"}
+	nykterstein := &LightUser{ID: "drnykterstein", Name: "DrNykterstein", Title: "GM"}
+
+	want := map[TVChannel]*TVGame{
+		ChannelBot:           {User: leela, Rating: 2660, GameID: "aaaaaaaa", Color: Black},
+		ChannelBlitz:         {User: nykterstein, Rating: 3200, GameID: "bbbbbbbb", Color: White},
+		ChannelBest:          {User: nykterstein, Rating: 3200, GameID: "bbbbbbbb", Color: White},
+		ChannelKingOfTheHill: {User: &LightUser{ID: "koth", Name: "KotH"}, Rating: 2100, GameID: "cccccccc", Color: White},
+	}
+
+	if diff := cmp.Diff(channels, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestTVService_ChannelFeed(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/tv/rapid/feed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `
+		{
+		  "t": "featured",
+		  "d": {
+		    "id": "cccccccc",
+		    "orientation": "white",
+		    "players": [
+		      {"color": "white", "user": {"name": "A", "id": "a"}, "rating": 2400, "seconds": 600},
+		      {
+		        "color": "black",
+		        "user": {"name": "B", "id": "b", "title": "IM"},
+		        "rating": 2450,
+		        "seconds": 600
+		      }
+		    ],
+		    "fen": "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR"
+		  }
+		}
+{"t":"fen","d":{"fen":"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR","lm":"e2e4","wc":600,"bc":600}}
+`)
+	})
+
+	evCh, errCh := client.TV.ChannelFeed(context.Background(), ChannelRapid)
+
+	var events []*TVFeedEvent
+	for ev := range evCh {
+		events = append(events, ev)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Errorf("TV.ChannelFeed returned error: %v", err)
+	}
+
+	want := []*TVFeedEvent{
+		{
+			Type: TVFeatured,
+			Featured: &TVFeaturedGame{
+				ID:          "cccccccc",
+				Orientation: White,
+				Players: []*TVFeaturedPlayer{
+					{Color: White, User: &LightUser{ID: "a", Name: "A"}, Rating: 2400, Seconds: 600},
+					{Color: Black, User: &LightUser{ID: "b", Name: "B", Title: "IM"}, Rating: 2450, Seconds: 600},
+				},
+				Fen: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+			},
+		},
+		{
+			Type: TVFen,
+			Move: &StreamedMove{
+				Fen:        "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR",
+				LastMove:   "e2e4",
+				WhiteClock: 600,
+				BlackClock: 600,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(events, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestTVService_ChannelGames(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/tv/blitz", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RawQuery, "clocks=true&nb=2&pgnInJson=true"; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		w.Header().Set("Content-Type", mediaTypeEnableNDJson)
+		fmt.Fprint(w, `{"id":"dddddddd","rated":true,"variant":"standard","speed":"blitz","status":"started"}
+{"id":"eeeeeeee","rated":false,"variant":"standard","speed":"blitz","status":"started"}
+`)
+	})
+
+	opts := ChannelGamesOptions{Nb: 2, ExportOptions: ExportOptions{Clocks: Bool(true)}}
+	games, _, err := client.TV.ChannelGames(context.Background(), ChannelBlitz, opts)
+
+	if err != nil {
+		t.Fatalf("TV.ChannelGames returned error: %v", err)
+	}
+
+	if len(games) != 2 || games[0].ID != "dddddddd" || games[1].Rated {
+		t.Errorf("TV.ChannelGames returned %+v", games)
+	}
+}
@@ -2,7 +2,9 @@ package lichess
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/pkg/errors"
 )
@@ -12,6 +14,9 @@ type UsersService service
 type User struct {
 	ID        string `json:"id"`
 	Username  string `json:"username"`
+	Title     string `json:"title,omitempty"`
+	Patron    bool   `json:"patron,omitempty"`
+	Flair     string `json:"flair,omitempty"`
 	Online    bool   `json:"online"`
 	CreatedAt int64  `json:"createdAt"`
 	SeenAt    int64  `json:"seenAt"`
@@ -24,6 +29,10 @@ type User struct {
 	CompletionRate int      `json:"completionRate,omitempty"`
 	Profile        *Profile `json:"profile,omitempty"`
 	Stat           *Stats   `json:"count,omitempty"`
+	// Disabled is set for closed accounts, which send no other data.
+	Disabled bool `json:"disabled,omitempty"`
+	// TosViolation marks accounts flagged for breaking the terms of service.
+	TosViolation bool `json:"tosViolation,omitempty"`
 }
 
 type Profile struct {
@@ -55,7 +64,7 @@ type Stats struct {
 }
 
 func (s *UsersService) Get(ctx context.Context, username string) (*User, *Response, error) {
-	u := fmt.Sprintf("/api/user/%v", username)
+	u := fmt.Sprintf("api/user/%v", username)
 	req, err := s.client.NewRequest("GET", u, nil)
 
 	if err != nil {
@@ -71,3 +80,314 @@ func (s *UsersService) Get(ctx context.Context, username string) (*User, *Respon
 
 	return player, resp, nil
 }
+
+// Crosstable is the total score of each user in their games against each
+// other, keyed by user ID.
+type Crosstable struct {
+	Users   map[string]float64 `json:"users"`
+	NbGames int                `json:"nbGames"`
+	// Matchup holds the scores of the current match, i.e. the games played in
+	// a row recently, when one is ongoing and was requested.
+	Matchup *Crosstable `json:"matchup,omitempty"`
+}
+
+func (s *UsersService) Crosstable(
+	ctx context.Context, user1, user2 string, matchup bool,
+) (*Crosstable, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("api/crosstable/%v/%v", user1, user2), struct {
+		Matchup bool `url:"matchup,omitempty"`
+	}{matchup})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	crosstable := new(Crosstable)
+	resp, err := s.client.Do(ctx, req, crosstable)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return crosstable, resp, nil
+}
+
+// LeaderboardEntry is a player of a leaderboard with their rating in its perf.
+type LeaderboardEntry struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Title    string `json:"title,omitempty"`
+	Patron   bool   `json:"patron,omitempty"`
+	Online   bool   `json:"online,omitempty"`
+	Rating   int    `json:"-"`
+	// Progress is the rating change over the last twelve days.
+	Progress int `json:"-"`
+}
+
+func (e *LeaderboardEntry) UnmarshalJSON(data []byte) error {
+	type entry LeaderboardEntry
+
+	aux := struct {
+		*entry
+		Perfs map[PerfType]struct {
+			Rating   int `json:"rating"`
+			Progress int `json:"progress"`
+		} `json:"perfs"`
+	}{entry: (*entry)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	// Leaderboards only send the perf they rank.
+	for _, perf := range aux.Perfs {
+		e.Rating = perf.Rating
+		e.Progress = perf.Progress
+	}
+
+	return nil
+}
+
+// Leaderboards returns the top 10 players of each perf.
+func (s *UsersService) Leaderboards(ctx context.Context) (map[PerfType][]*LeaderboardEntry, *Response, error) {
+	req, err := s.client.NewRequest("GET", "api/player", nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	leaderboards := make(map[PerfType][]*LeaderboardEntry)
+	resp, err := s.client.Do(ctx, req, &leaderboards)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return leaderboards, resp, nil
+}
+
+// Leaderboard returns the top nb players of a perf, at most 200.
+func (s *UsersService) Leaderboard(ctx context.Context, nb int, perf PerfType) ([]*LeaderboardEntry, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("api/player/top/%d/%v", nb, perf), nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Accept", mediaTypeLichessV3)
+
+	var leaderboard struct {
+		Users []*LeaderboardEntry `json:"users"`
+	}
+
+	resp, err := s.client.Do(ctx, req, &leaderboard)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return leaderboard.Users, resp, nil
+}
+
+type UserStatus struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title,omitempty"`
+	Patron    bool   `json:"patron,omitempty"`
+	Online    bool   `json:"online,omitempty"`
+	Playing   bool   `json:"playing,omitempty"`
+	Streaming bool   `json:"streaming,omitempty"`
+	// PlayingID is the game being played, only sent when requested.
+	PlayingID string `json:"playingId,omitempty"`
+}
+
+const maxStatusIDs = 100
+
+// Status returns the real-time status of the users. Lichess accepts up to 100
+// IDs per request, so longer lists are split into several requests; the
+// Response of the last one is returned.
+func (s *UsersService) Status(ctx context.Context, IDs []string, withGameIDs bool) ([]*UserStatus, *Response, error) {
+	statuses := make([]*UserStatus, 0, len(IDs))
+
+	var resp *Response
+
+	for start := 0; start < len(IDs); start += maxStatusIDs {
+		end := start + maxStatusIDs
+		if end > len(IDs) {
+			end = len(IDs)
+		}
+
+		u, err := addOptions("api/users/status", struct {
+			IDs         []string `url:"ids"`
+			WithGameIDs bool     `url:"withGameIds,omitempty"`
+		}{IDs[start:end], withGameIDs})
+		if err != nil {
+			return nil, resp, errors.WithStack(err)
+		}
+
+		req, err := s.client.NewRequest("GET", u, nil)
+
+		if err != nil {
+			return nil, resp, errors.WithStack(err)
+		}
+
+		var batch []*UserStatus
+
+		resp, err = s.client.Do(ctx, req, &batch)
+
+		if err != nil {
+			return nil, resp, err
+		}
+
+		statuses = append(statuses, batch...)
+	}
+
+	return statuses, resp, nil
+}
+
+type Streamer struct {
+	LightUser
+	Stream   *Stream          `json:"stream,omitempty"`
+	Streamer *StreamerProfile `json:"streamer,omitempty"`
+}
+
+type Stream struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+	Lang    string `json:"lang,omitempty"`
+}
+
+type StreamerProfile struct {
+	Name        string `json:"name"`
+	Headline    string `json:"headline,omitempty"`
+	Description string `json:"description,omitempty"`
+	Twitch      string `json:"twitch,omitempty"`
+	YouTube     string `json:"youTube,omitempty"`
+	Image       string `json:"image,omitempty"`
+}
+
+// LiveStreamers returns the lichess streamers currently streaming.
+func (s *UsersService) LiveStreamers(ctx context.Context) ([]*Streamer, *Response, error) {
+	req, err := s.client.NewRequest("GET", "api/streamer/live", nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	var streamers []*Streamer
+
+	resp, err := s.client.Do(ctx, req, &streamers)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return streamers, resp, nil
+}
+
+type AutocompleteUser struct {
+	LightUser
+	Online bool `json:"online,omitempty"`
+}
+
+type AutocompleteOptions struct {
+	// Friend restricts the results to the users the current user follows.
+	Friend bool `url:"friend,omitempty"`
+}
+
+// Autocomplete returns the usernames starting with term, which must be at
+// least 3 characters long.
+func (s *UsersService) Autocomplete(
+	ctx context.Context, term string, opts AutocompleteOptions,
+) ([]string, *Response, error) {
+	var names []string
+
+	resp, err := s.autocomplete(ctx, term, false, opts, &names)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return names, resp, nil
+}
+
+// AutocompleteUsers is Autocomplete returning users rather than names.
+func (s *UsersService) AutocompleteUsers(
+	ctx context.Context, term string, opts AutocompleteOptions,
+) ([]*AutocompleteUser, *Response, error) {
+	var users struct {
+		Result []*AutocompleteUser `json:"result"`
+	}
+
+	resp, err := s.autocomplete(ctx, term, true, opts, &users)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return users.Result, resp, nil
+}
+
+func (s *UsersService) autocomplete(
+	ctx context.Context, term string, object bool, opts AutocompleteOptions, v interface{},
+) (*Response, error) {
+	u, err := addOptions("api/player/autocomplete", struct {
+		Term   string `url:"term"`
+		Object bool   `url:"object,omitempty"`
+		AutocompleteOptions
+	}{term, object, opts})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.client.Do(ctx, req, v)
+}
+
+// Note is a private note the current user wrote about another user.
+type Note struct {
+	From *LightUser `json:"from"`
+	To   *LightUser `json:"to"`
+	Text string     `json:"text"`
+	Date Timestamp  `json:"date"`
+}
+
+// AddNote adds a private note about a user, only visible to the current user.
+func (s *UsersService) AddNote(ctx context.Context, username, text string) (*Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("api/user/%v/note", username), url.Values{"text": {text}})
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// Notes returns the private notes the current user wrote about a user.
+func (s *UsersService) Notes(ctx context.Context, username string) ([]*Note, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("api/user/%v/note", username), nil)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	var notes []*Note
+
+	resp, err := s.client.Do(ctx, req, &notes)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notes, resp, nil
+}
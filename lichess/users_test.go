@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -90,3 +91,344 @@ func TestUsersService_Get(t *testing.T) {
 		t.Errorf("Responses do not match. Diff: %+v", diff)
 	}
 }
+
+func TestUsersService_Crosstable(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/crosstable/neio/thibault", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.RawQuery, "matchup=true"; got != want {
+			t.Errorf("query is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "users": {"neio": 201.5, "thibault": 144.5},
+		  "nbGames": 346,
+		  "matchup": {"users": {"neio": 44, "thibault": 43}, "nbGames": 87}
+		}
+		`)
+	})
+
+	crosstable, _, err := client.Users.Crosstable(context.Background(), "neio", "thibault", true)
+
+	if err != nil {
+		t.Fatalf("Users.Crosstable returned error: %v", err)
+	}
+
+	want := &Crosstable{
+		Users:   map[string]float64{"neio": 201.5, "thibault": 144.5},
+		NbGames: 346,
+		Matchup: &Crosstable{Users: map[string]float64{"neio": 44, "thibault": 43}, NbGames: 87},
+	}
+
+	if diff := cmp.Diff(crosstable, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestUsersService_Leaderboards(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/player", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		{
+		  "bullet": [
+		    {
+		      "id": "penguingim1",
+		      "username": "penguingim1",
+		      "perfs": {"bullet": {"rating": 3276, "progress": -12}},
+		      "title": "GM",
+		      "online": true
+		    }
+		  ],
+		  "blitz": [
+		    {
+		      "id": "drnykterstein",
+		      "username": "DrNykterstein",
+		      "perfs": {"blitz": {"rating": 3245, "progress": 20}},
+		      "title": "GM",
+		      "patron": true
+		    }
+		  ]
+		}
+		`)
+	})
+
+	leaderboards, _, err := client.Users.Leaderboards(context.Background())
+
+	if err != nil {
+		t.Fatalf("Users.Leaderboards returned error: %v", err)
+	}
+
+	want := map[PerfType][]*LeaderboardEntry{
+		PerfBullet: {{ID: "penguingim1", Username: "penguingim1", Title: "GM", Online: true, Rating: 3276, Progress: -12}},
+		PerfBlitz:  {{ID: "drnykterstein", Username: "DrNykterstein", Title: "GM", Patron: true, Rating: 3245, Progress: 20}},
+	}
+
+	if diff := cmp.Diff(leaderboards, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestUsersService_Leaderboard(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/player/top/2/rapid", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Accept"), mediaTypeLichessV3; got != want {
+			t.Errorf("Accept header is %q, want %q", got, want)
+		}
+
+		fmt.Fprint(w, `
+		{
+		  "users": [
+		    {"id": "a", "username": "A", "perfs": {"rapid": {"rating": 2800, "progress": 5}}},
+		    {"id": "b", "username": "B", "perfs": {"rapid": {"rating": 2790, "progress": 0}}}
+		  ]
+		}
+		`)
+	})
+
+	leaderboard, _, err := client.Users.Leaderboard(context.Background(), 2, PerfRapid)
+
+	if err != nil {
+		t.Fatalf("Users.Leaderboard returned error: %v", err)
+	}
+
+	want := []*LeaderboardEntry{
+		{ID: "a", Username: "A", Rating: 2800, Progress: 5},
+		{ID: "b", Username: "B", Rating: 2790},
+	}
+
+	if diff := cmp.Diff(leaderboard, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestUsersService_Status(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	var requests int
+
+	mux.HandleFunc("/api/users/status", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		if len(ids) > maxStatusIDs {
+			t.Errorf("request has %d IDs, want at most %d", len(ids), maxStatusIDs)
+		}
+
+		if got, want := r.URL.Query().Get("withGameIds"), "true"; got != want {
+			t.Errorf("withGameIds is %q, want %q", got, want)
+		}
+
+		statuses := make([]string, len(ids))
+		for i, id := range ids {
+			statuses[i] = fmt.Sprintf(`{"id":%q,"name":%q,"online":%v}`, id, strings.ToUpper(id), i%2 == 0)
+		}
+
+		fmt.Fprintf(w, "[%v]", strings.Join(statuses, ","))
+	})
+
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("user%d", i)
+	}
+
+	statuses, _, err := client.Users.Status(context.Background(), ids, true)
+
+	if err != nil {
+		t.Fatalf("Users.Status returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Users.Status made %d requests, want 2", requests)
+	}
+
+	if len(statuses) != 150 || statuses[149].ID != "user149" {
+		t.Fatalf("Users.Status returned %d statuses", len(statuses))
+	}
+
+	if want := (&UserStatus{ID: "user100", Name: "USER100", Online: true}); !cmp.Equal(statuses[100], want) {
+		t.Errorf("Users.Status returned %+v, want %+v", statuses[100], want)
+	}
+}
+
+func TestUsersService_LiveStreamers(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/streamer/live", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+		[
+		  {
+		    "id": "chessnetwork",
+		    "name": "ChessNetwork",
+		    "patron": true,
+		    "stream": {"service": "twitch", "status": "Blitz with viewers", "lang": "en"},
+		    "streamer": {
+		      "name": "ChessNetwork",
+		      "headline": "Chess content",
+		      "twitch": "https://twitch.tv/chessnetwork"
+		    }
+		  }
+		]
+		`)
+	})
+
+	streamers, _, err := client.Users.LiveStreamers(context.Background())
+
+	if err != nil {
+		t.Fatalf("Users.LiveStreamers returned error: %v", err)
+	}
+
+	want := []*Streamer{{
+		LightUser: LightUser{ID: "chessnetwork", Name: "ChessNetwork", Patron: true},
+		Stream:    &Stream{Service: "twitch", Status: "Blitz with viewers", Lang: "en"},
+		Streamer: &StreamerProfile{
+			Name:     "ChessNetwork",
+			Headline: "Chess content",
+			Twitch:   "https://twitch.tv/chessnetwork",
+		},
+	}}
+
+	if diff := cmp.Diff(streamers, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestUsersService_Get_publicFlags(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/user/closed", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"closed","username":"Closed","disabled":true}`)
+	})
+
+	mux.HandleFunc("/api/user/gm", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"gm","username":"GM","title":"GM","patron":true,"flair":"activity.lichess","tosViolation":true}`)
+	})
+
+	closed, _, err := client.Users.Get(context.Background(), "closed")
+
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+
+	if !closed.Disabled {
+		t.Errorf("Users.Get returned %+v, want a disabled user", closed)
+	}
+
+	gm, _, err := client.Users.Get(context.Background(), "gm")
+
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+
+	if gm.Title != "GM" || !gm.Patron || gm.Flair != "activity.lichess" || !gm.TosViolation {
+		t.Errorf("Users.Get returned %+v", gm)
+	}
+}
+
+func TestUsersService_Autocomplete(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/player/autocomplete", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		if got, want := r.URL.Query().Get("term"), "thib"; got != want {
+			t.Errorf("term is %q, want %q", got, want)
+		}
+
+		if r.URL.Query().Get("object") == "true" {
+			if got, want := r.URL.Query().Get("friend"), "true"; got != want {
+				t.Errorf("friend is %q, want %q", got, want)
+			}
+
+			fmt.Fprint(w, `{"result":[{"id":"thibault","name":"thibault","patron":true,"online":true}]}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `["thibault","thibaultd"]`)
+	})
+
+	names, _, err := client.Users.Autocomplete(context.Background(), "thib", AutocompleteOptions{})
+
+	if err != nil {
+		t.Fatalf("Users.Autocomplete returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(names, []string{"thibault", "thibaultd"}); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+
+	users, _, err := client.Users.AutocompleteUsers(context.Background(), "thib", AutocompleteOptions{Friend: true})
+
+	if err != nil {
+		t.Fatalf("Users.AutocompleteUsers returned error: %v", err)
+	}
+
+	want := []*AutocompleteUser{{LightUser: LightUser{ID: "thibault", Name: "thibault", Patron: true}, Online: true}}
+
+	if diff := cmp.Diff(users, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
+
+func TestUsersService_Notes(t *testing.T) {
+	client, mux, teardown := setUp()
+	defer teardown()
+
+	mux.HandleFunc("/api/user/rival/note", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if got, want := r.FormValue("text"), "Plays the Najdorf"; got != want {
+				t.Errorf("text is %q, want %q", got, want)
+			}
+
+			fmt.Fprint(w, `{"ok":true}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `
+		[
+		  {
+		    "from": {"id": "me", "name": "Me"},
+		    "to": {"id": "rival", "name": "Rival"},
+		    "text": "Plays the Najdorf",
+		    "date": 1620000000000
+		  }
+		]
+		`)
+	})
+
+	if _, err := client.Users.AddNote(context.Background(), "rival", "Plays the Najdorf"); err != nil {
+		t.Errorf("Users.AddNote returned error: %v", err)
+	}
+
+	notes, _, err := client.Users.Notes(context.Background(), "rival")
+
+	if err != nil {
+		t.Fatalf("Users.Notes returned error: %v", err)
+	}
+
+	want := []*Note{{
+		From: &LightUser{ID: "me", Name: "Me"},
+		To:   &LightUser{ID: "rival", Name: "Rival"},
+		Text: "Plays the Najdorf",
+		Date: fromMillis(1620000000000),
+	}}
+
+	if diff := cmp.Diff(notes, want); diff != "" {
+		t.Errorf("Responses do not match. Diff: %+v", diff)
+	}
+}
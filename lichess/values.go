@@ -0,0 +1,184 @@
+package lichess
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// addOptions adds the parameters encoded from opts to the query string of s.
+func addOptions(s string, opts interface{}) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+
+	qs, err := encodeValues(opts)
+	if err != nil {
+		return s, err
+	}
+
+	q := u.Query()
+	for k, v := range qs {
+		q[k] = v
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// encodeValues turns a struct into url.Values following the `url:"name"` tags
+// of its fields. Fields tagged "-" are skipped, "omitempty" drops zero values,
+// nil pointers are always dropped, embedded structs are flattened and slices
+// are joined with commas, the way Lichess expects list parameters. Times are
+// sent as milliseconds since the epoch.
+func encodeValues(v interface{}) (url.Values, error) {
+	values := url.Values{}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return values, nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("values: expected a struct, got %v", rv.Kind())
+	}
+
+	return values, addValues(values, rv)
+}
+
+func addValues(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+
+		if field.Anonymous && name == "" && indirect(fv).Kind() == reflect.Struct && fv.Type() != timeType {
+			if fv = indirect(fv); fv.IsValid() {
+				if err := addValues(values, fv); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+
+			fv = fv.Elem()
+		} else if opts.contains("omitempty") && fv.IsZero() {
+			continue
+		}
+
+		s, err := formatValue(fv)
+		if err != nil {
+			return fmt.Errorf("values: field %v: %v", field.Name, err)
+		}
+
+		values.Set(name, s)
+	}
+
+	return nil
+}
+
+func formatValue(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10), nil
+	}
+
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+
+		for i := range parts {
+			s, err := formatValue(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+
+			parts[i] = s
+		}
+
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported kind %v", v.Kind())
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+type tagOptions []string
+
+func parseTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+
+	return parts[0], parts[1:]
+}
+
+func (o tagOptions) contains(option string) bool {
+	for _, s := range o {
+		if s == option {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package lichess
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncodeValues(t *testing.T) {
+	type inner struct {
+		Clocks *bool `url:"clocks,omitempty"`
+	}
+
+	type options struct {
+		Name    string   `url:"name"`
+		Rated   bool     `url:"rated,omitempty"`
+		Limit   int      `url:"clock.limit"`
+		Skipped string   `url:"-"`
+		Empty   string   `url:"empty,omitempty"`
+		Users   []string `url:"users,omitempty"`
+		Color   *string  `url:"color,omitempty"`
+		inner
+	}
+
+	values, err := encodeValues(&options{
+		Name:    "test",
+		Limit:   300,
+		Skipped: "skipped",
+		Users:   []string{"alice", "bob"},
+		inner:   inner{Clocks: Bool(false)},
+	})
+
+	if err != nil {
+		t.Fatalf("encodeValues returned error: %v", err)
+	}
+
+	want := "clock.limit=300&clocks=false&name=test&users=alice%2Cbob"
+
+	if diff := cmp.Diff(values.Encode(), want); diff != "" {
+		t.Errorf("Values do not match. Diff: %+v", diff)
+	}
+}
+
+func TestEncodeValues_ExpectError(t *testing.T) {
+	if _, err := encodeValues("not a struct"); err == nil {
+		t.Error("encodeValues should return an error for non-struct values")
+	}
+}
+
+func TestAddOptions(t *testing.T) {
+	opts := ListOptions{
+		Since:    time.Unix(1620000000, 0),
+		PerfType: []PerfType{PerfBlitz, PerfRapid},
+		Rated:    Bool(true),
+		ExportOptions: ExportOptions{
+			Clocks: Bool(true),
+		},
+	}
+
+	u, err := addOptions("/api/games/user/test?max=10", opts)
+
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+
+	want := "/api/games/user/test?clocks=true&max=10&perfType=blitz%2Crapid&rated=true&since=1620000000000"
+
+	if diff := cmp.Diff(u, want); diff != "" {
+		t.Errorf("URLs do not match. Diff: %+v", diff)
+	}
+}